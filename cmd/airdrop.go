@@ -0,0 +1,423 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	appconfig "github.com/CharlieAIO/sol-cloud/internal/config"
+	"github.com/CharlieAIO/sol-cloud/internal/solana"
+	"github.com/CharlieAIO/sol-cloud/internal/ui"
+	"github.com/CharlieAIO/sol-cloud/internal/validator"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const airdropPollInterval = time.Second
+
+var (
+	airdropName     string
+	airdropTimeout  time.Duration
+	airdropFromFile string
+	fundName        string
+	fundTimeout     time.Duration
+)
+
+var airdropCmd = &cobra.Command{
+	Use:   "airdrop <address>[:amount]...",
+	Short: "Airdrop SOL on a deployed validator",
+	Long: `Send SOL to one or more addresses on a deployed validator and wait for each
+airdrop to be confirmed.
+
+Amounts are in SOL. A missing amount defaults to 1000 SOL, or to
+validator.faucet.max_amount when the faucet is enabled. When validator.faucet is
+enabled the requests go through the /faucet endpoint, so its per-request
+maximum and quotas apply. Otherwise requestAirdrop is called on the RPC
+endpoint directly.`,
+	Example: `  sol-cloud airdrop 9xQeWvG816bUx9EPjHmaT23yvVM2ZWbrrpZb9PusVFin:2
+  sol-cloud airdrop 9xQeWvG816bUx9EPjHmaT23yvVM2ZWbrrpZb9PusVFin 0.5
+  sol-cloud airdrop --from-file wallets.txt --name my-validator`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		values := args
+		// Keep the `airdrop <address> <amount>` form working.
+		if len(args) == 2 && !strings.Contains(args[0], ":") && !validator.IsAddress(strings.TrimSpace(args[1])) {
+			values = []string{args[0] + ":" + args[1]}
+		}
+		if strings.TrimSpace(airdropFromFile) != "" {
+			fileValues, err := readAirdropFile(airdropFromFile)
+			if err != nil {
+				return err
+			}
+			values = append(append([]string(nil), values...), fileValues...)
+		}
+		if len(values) == 0 {
+			return errors.New("pass at least one address or --from-file")
+		}
+
+		faucet, err := loadFaucetConfig()
+		if err != nil {
+			return err
+		}
+		defaultLamports := validator.DefaultAirdropAmount * solana.LamportsPerSOL
+		if faucet.Enabled {
+			defaultLamports = faucet.MaxAmount * solana.LamportsPerSOL
+		}
+		requests, err := parseAirdropTargets(values, defaultLamports)
+		if err != nil {
+			return err
+		}
+		return runAirdrops(cmd, airdropName, airdropTimeout, faucet, requests)
+	},
+}
+
+var fundCmd = &cobra.Command{
+	Use:   "fund [name]",
+	Short: "Replay the configured airdrop_accounts against a running validator",
+	Long: `Send every validator.airdrop_accounts entry from the project config (or the
+file passed with --config) to a running validator, without a redeploy or
+restart. Airdrops go through the /faucet endpoint when validator.faucet is
+enabled, so its quotas apply.`,
+	Example: `  sol-cloud fund
+  sol-cloud fund --config staging.yml my-validator`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.TrimSpace(fundName)
+		if name == "" && len(args) > 0 {
+			name = strings.TrimSpace(args[0])
+		}
+
+		var entries []validator.AirdropEntry
+		if err := viper.UnmarshalKey("validator.airdrop_accounts", &entries); err != nil {
+			return fmt.Errorf("invalid validator.airdrop_accounts in project config: %w", err)
+		}
+		cfg := validator.DefaultConfig()
+		cfg.AirdropAccounts = entries
+		cfg.ApplyDefaults()
+		if len(cfg.AirdropAccounts) == 0 {
+			return errors.New("validator.airdrop_accounts is empty; nothing to fund")
+		}
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid validator config: %w", err)
+		}
+		requests := make([]airdropRequest, 0, len(cfg.AirdropAccounts))
+		for _, entry := range cfg.AirdropAccounts {
+			requests = append(requests, airdropRequest{Address: entry.Address, Lamports: entry.Amount * solana.LamportsPerSOL})
+		}
+
+		faucet, err := loadFaucetConfig()
+		if err != nil {
+			return err
+		}
+		return runAirdrops(cmd, name, fundTimeout, faucet, requests)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(airdropCmd, fundCmd)
+
+	airdropCmd.Flags().StringVar(&airdropName, "name", "", "Deployment name (defaults to last deployment from local state)")
+	airdropCmd.Flags().DurationVar(&airdropTimeout, "timeout", 60*time.Second, "Timeout for sending and confirming all airdrops")
+	airdropCmd.Flags().StringVar(&airdropFromFile, "from-file", "", "File with one address[:amount] per line; blank lines and # comments are ignored")
+	fundCmd.Flags().StringVar(&fundName, "name", "", "Deployment name (defaults to last deployment from local state)")
+	fundCmd.Flags().DurationVar(&fundTimeout, "timeout", 2*time.Minute, "Timeout for sending and confirming all airdrops")
+}
+
+type airdropDocument struct {
+	Name     string                  `json:"name" yaml:"name"`
+	Via      string                  `json:"via" yaml:"via"`
+	Airdrops []airdropResultDocument `json:"airdrops" yaml:"airdrops"`
+}
+
+// airdropResultDocument is one airdrop. Status is confirmed, failed, or
+// unconfirmed when the timeout passed first.
+type airdropResultDocument struct {
+	Address   string `json:"address" yaml:"address"`
+	Lamports  uint64 `json:"lamports" yaml:"lamports"`
+	Signature string `json:"signature,omitempty" yaml:"signature,omitempty"`
+	Status    string `json:"status" yaml:"status"`
+	Error     string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func (r airdropResultDocument) describe() string {
+	text := fmt.Sprintf("%s SOL %s", solana.FormatSOL(r.Lamports), r.Status)
+	if r.Signature != "" {
+		text += " " + r.Signature
+	}
+	if r.Error != "" {
+		text += ": " + r.Error
+	}
+	return text
+}
+
+type airdropRequest struct {
+	Address  string
+	Lamports uint64
+}
+
+// parseAirdropTargets converts "ADDRESS" or "ADDRESS:AMOUNT" values, with
+// amounts in SOL, into airdrop requests.
+func parseAirdropTargets(values []string, defaultLamports uint64) ([]airdropRequest, error) {
+	requests := make([]airdropRequest, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		address, amount, hasAmount := strings.Cut(value, ":")
+		address = strings.TrimSpace(address)
+		if !validator.IsAddress(address) {
+			return nil, fmt.Errorf("invalid address in %q", value)
+		}
+		lamports := defaultLamports
+		if hasAmount {
+			parsed, err := validator.ParseTokenAmount(strings.TrimSpace(amount), 9)
+			if err != nil || parsed == 0 {
+				return nil, fmt.Errorf("invalid amount in %q: must be a positive SOL amount with at most 9 decimals", value)
+			}
+			lamports = parsed
+		}
+		requests = append(requests, airdropRequest{Address: address, Lamports: lamports})
+	}
+	return requests, nil
+}
+
+// readAirdropFile returns the address[:amount] lines of path.
+func readAirdropFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open airdrop file: %w", err)
+	}
+	defer file.Close()
+
+	var values []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line != "" {
+			values = append(values, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read airdrop file: %w", err)
+	}
+	return values, nil
+}
+
+// runAirdrops sends requests to the deployment called name (or the last
+// deployment), waits for their signatures to confirm, and prints the results.
+// It returns an error when any airdrop did not confirm.
+func runAirdrops(cmd *cobra.Command, name string, timeout time.Duration, faucet validator.FaucetConfig, requests []airdropRequest) error {
+	if timeout <= 0 {
+		return fmt.Errorf("--timeout must be > 0")
+	}
+	if faucet.Enabled {
+		for _, request := range requests {
+			if request.Lamports > faucet.MaxAmount*solana.LamportsPerSOL {
+				return fmt.Errorf("%s SOL to %s exceeds validator.faucet.max_amount (%d SOL)", solana.FormatSOL(request.Lamports), request.Address, faucet.MaxAmount)
+			}
+		}
+	}
+
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+	state, err := appconfig.LoadState(projectDir)
+	if err != nil {
+		return fmt.Errorf("load local deployment state: %w", err)
+	}
+	record, err := resolveStatusRecord(state, strings.TrimSpace(name))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+	defer cancel()
+
+	client := solana.NewClient(authorizedRPCURL(record.RPCURL))
+	via := "rpc"
+	if faucet.Enabled {
+		via = "faucet"
+	}
+	results := make([]airdropResultDocument, len(requests))
+	progress := ui.NewProgress(progressOutput(cmd), 2)
+	progress.Start(fmt.Sprintf("Requesting %d airdrop(s) via %s", len(requests), via))
+	for i, request := range requests {
+		results[i] = airdropResultDocument{Address: request.Address, Lamports: request.Lamports}
+		var signature string
+		if faucet.Enabled {
+			signature, err = requestFaucetAirdrop(ctx, faucetURL(record.RPCURL), request.Address, request.Lamports)
+		} else {
+			signature, err = client.RequestAirdrop(ctx, request.Address, request.Lamports)
+		}
+		if err != nil {
+			results[i].Status = "failed"
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].Signature = signature
+	}
+
+	progress.Step("Confirming airdrops")
+	confirmAirdrops(ctx, client, results)
+	failed := 0
+	for _, result := range results {
+		if result.Status != "confirmed" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		progress.Fail(fmt.Sprintf("%d of %d airdrop(s) did not confirm", failed, len(results)))
+	} else {
+		progress.Success(fmt.Sprintf("%d airdrop(s) confirmed", len(results)))
+	}
+
+	out := cmd.OutOrStdout()
+	if structuredOutput() {
+		if err := writeOutputDocument(out, "airdrop", airdropDocument{Name: record.Name, Via: via, Airdrops: results}); err != nil {
+			return err
+		}
+	} else {
+		ui.Header(out, "Airdrop")
+		ui.Fields(out,
+			ui.Field{Label: "Validator", Value: record.Name},
+			ui.Field{Label: "Via", Value: via},
+		)
+		fields := make([]ui.Field, 0, len(results))
+		for _, result := range results {
+			fields = append(fields, ui.Field{Label: result.Address, Value: result.describe()})
+		}
+		ui.Fields(out, fields...)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d airdrop(s) did not confirm", failed, len(results))
+	}
+	return nil
+}
+
+// confirmAirdrops polls getSignatureStatuses until every sent airdrop is
+// confirmed or failed, or ctx is done. Airdrops still pending at that point
+// are marked unconfirmed.
+func confirmAirdrops(ctx context.Context, client *solana.Client, results []airdropResultDocument) {
+	for {
+		var pending []int
+		var signatures []string
+		for i, result := range results {
+			if result.Signature != "" && result.Status == "" {
+				pending = append(pending, i)
+				signatures = append(signatures, result.Signature)
+			}
+		}
+		if len(pending) == 0 {
+			return
+		}
+
+		statuses, err := client.GetSignatureStatuses(ctx, signatures)
+		if err == nil {
+			for j, status := range statuses {
+				switch {
+				case status == nil:
+				case status.Failed():
+					results[pending[j]].Status = "failed"
+					results[pending[j]].Error = "transaction error: " + string(status.Err)
+				case status.Confirmed():
+					results[pending[j]].Status = "confirmed"
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			for _, i := range pending {
+				if results[i].Status == "" {
+					results[i].Status = "unconfirmed"
+					if err != nil {
+						results[i].Error = err.Error()
+					}
+				}
+			}
+			return
+		case <-time.After(airdropPollInterval):
+		}
+	}
+}
+
+// loadFaucetConfig reads validator.faucet from the project config with
+// defaults applied.
+func loadFaucetConfig() (validator.FaucetConfig, error) {
+	var cfg validator.Config
+	if err := viper.UnmarshalKey("validator.faucet", &cfg.Faucet); err != nil {
+		return cfg.Faucet, fmt.Errorf("invalid validator.faucet in project config: %w", err)
+	}
+	cfg.ApplyDefaults()
+	return cfg.Faucet, nil
+}
+
+// faucetURL returns the /faucet endpoint of a deployment, with an API key when
+// the project config has one.
+func faucetURL(rpcURL string) string {
+	return authorizedRPCURL(strings.TrimRight(strings.TrimSpace(rpcURL), "/") + "/faucet")
+}
+
+// requestFaucetAirdrop posts to the nginx faucet and returns the airdrop
+// signature. Quota and cap rejections are returned with the faucet's message.
+func requestFaucetAirdrop(ctx context.Context, url, address string, lamports uint64) (string, error) {
+	body, err := json.Marshal(map[string]any{
+		"address": address,
+		"amount":  json.Number(solana.FormatSOL(lamports)),
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal faucet request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("create faucet request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("faucet request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return "", fmt.Errorf("read faucet response: %w", err)
+	}
+	var decoded struct {
+		Signature string `json:"signature"`
+		Error     string `json:"error"`
+	}
+	if err := json.Unmarshal(responseBody, &decoded); err != nil {
+		return "", fmt.Errorf("faucet status %d: %s", resp.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+	if resp.StatusCode != http.StatusOK {
+		if decoded.Error == "" {
+			decoded.Error = strings.TrimSpace(string(responseBody))
+		}
+		return "", fmt.Errorf("faucet status %d: %s", resp.StatusCode, decoded.Error)
+	}
+	if decoded.Signature == "" {
+		return "", errors.New("faucet response has no signature")
+	}
+	return decoded.Signature, nil
+}
+
+// faucetSummary describes the faucet for the deploy summary, or "" when it is
+// disabled.
+func faucetSummary(cfg validator.FaucetConfig, rpcURL string) string {
+	if !cfg.Enabled {
+		return ""
+	}
+	return fmt.Sprintf("%s/faucet max=%d SOL per request, %d SOL per address and %d SOL per IP every %s",
+		strings.TrimRight(rpcURL, "/"), cfg.MaxAmount, cfg.AddressQuota, cfg.IPQuota, cfg.Window)
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -12,7 +11,9 @@ import (
 	"strings"
 
 	appconfig "github.com/CharlieAIO/sol-cloud/internal/config"
+	"github.com/CharlieAIO/sol-cloud/internal/solana"
 	"github.com/CharlieAIO/sol-cloud/internal/ui"
+	"github.com/CharlieAIO/sol-cloud/internal/validator"
 	"github.com/spf13/cobra"
 )
 
@@ -34,15 +35,23 @@ var (
 	cloneAccountsDir     string
 	cloneIncludeProgData bool
 	cloneWriteAcctFlags  bool
+	cloneMintAuthority   string
+	cloneFreezeAuthority string
+	cloneOwnedAccounts   bool
+	cloneFilterSize      uint64
+	cloneFilterMemcmp    []string
+	cloneMaxAccounts     int
 )
 
 var cloneCmd = &cobra.Command{
 	Use:   "clone-program <program-id>",
 	Short: "Clone a Solana program binary from an RPC endpoint",
-	Long:  "Dump a program binary with the Solana CLI and optionally deploy it to a managed validator.",
+	Long:  "Dump a program binary over JSON-RPC and optionally deploy it to a managed validator with the Solana CLI.",
 	Example: `  sol-cloud clone-program TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA
   sol-cloud clone-program <program-id> --source-rpc https://api.mainnet-beta.solana.com --out ./artifacts/program.so
   sol-cloud clone-program <program-id> --account EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v
+  sol-cloud clone-program <program-id> --account EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v --mint-authority ~/.config/solana/id.json
+  sol-cloud clone-program <program-id> --owned-accounts --filter-size 165 --filter-memcmp 0:EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v
   sol-cloud clone-program <program-id> --deploy
   sol-cloud clone-program <program-id> --deploy --target-rpc https://sol-cloud-1a2b3c4d.fly.dev --keypair ~/.config/solana/id.json`,
 	Args: cobra.ExactArgs(1),
@@ -52,8 +61,10 @@ var cloneCmd = &cobra.Command{
 			return fmt.Errorf("invalid program id: %q", programID)
 		}
 
-		if _, err := exec.LookPath("solana"); err != nil {
-			return fmt.Errorf("solana CLI not found in PATH: %w", err)
+		if cloneDeploy {
+			if _, err := exec.LookPath("solana"); err != nil {
+				return fmt.Errorf("solana CLI not found in PATH (required by --deploy): %w", err)
+			}
 		}
 
 		projectDir, err := os.Getwd()
@@ -61,6 +72,29 @@ var cloneCmd = &cobra.Command{
 			return fmt.Errorf("get working directory: %w", err)
 		}
 
+		var mintOverride *solana.MintAuthorityOverride
+		if strings.TrimSpace(cloneMintAuthority) != "" {
+			override, err := loadMintAuthorityOverride(projectDir, cloneMintAuthority, cloneFreezeAuthority)
+			if err != nil {
+				return err
+			}
+			mintOverride = &override
+		} else if strings.TrimSpace(cloneFreezeAuthority) != "" {
+			return errors.New("--freeze-authority requires --mint-authority")
+		}
+
+		ownedFilter := solana.ProgramAccountsFilter{DataSize: cloneFilterSize}
+		for _, raw := range cloneFilterMemcmp {
+			memcmp, err := solana.ParseMemcmpFilter(raw)
+			if err != nil {
+				return fmt.Errorf("invalid --filter-memcmp: %w", err)
+			}
+			ownedFilter.Memcmp = append(ownedFilter.Memcmp, memcmp)
+		}
+		if !cloneOwnedAccounts && (cloneFilterSize > 0 || len(ownedFilter.Memcmp) > 0) {
+			return errors.New("--filter-size and --filter-memcmp require --owned-accounts")
+		}
+
 		sourceRPC := strings.TrimSpace(cloneSourceRPC)
 		if sourceRPC == "" {
 			sourceRPC = defaultCloneSourceRPC
@@ -77,55 +111,94 @@ var cloneCmd = &cobra.Command{
 		}
 
 		out := cmd.OutOrStdout()
+		result := cloneDocument{
+			ProgramID:  programID,
+			SourceRPC:  sourceRPC,
+			BinaryPath: outPath,
+			Accounts:   []accountSnapshotRecord{},
+		}
 		totalSteps := 3
-		if len(cloneAccounts) > 0 || cloneIncludeProgData {
+		if len(cloneAccounts) > 0 || cloneIncludeProgData || cloneOwnedAccounts {
 			totalSteps++
 		}
 		if cloneDeploy {
 			totalSteps++
 		}
-		progress := ui.NewProgress(out, totalSteps)
+		progress := ui.NewProgress(progressOutput(cmd), totalSteps)
 		progress.Start("Dumping program binary")
-		dumpOutput, err := runSolana(cmd.Context(), "program", "dump", "-u", sourceRPC, programID, outPath)
+		client := solana.NewClient(sourceRPC)
+		binary, err := client.DumpProgram(cmd.Context(), programID)
 		if err != nil {
 			progress.Fail("Clone failed")
-			return fmt.Errorf("solana program dump failed: %w\n%s", err, strings.TrimSpace(dumpOutput))
+			return fmt.Errorf("dump program %s: %w", programID, err)
+		}
+		if err := os.WriteFile(outPath, binary, 0o644); err != nil {
+			progress.Fail("Clone failed")
+			return fmt.Errorf("write program binary: %w", err)
 		}
 		progress.Step("Program binary written")
 
 		progress.Step("Collecting account snapshots")
-		accountIDs, collectErr := collectAccountIDsForClone(cmd.Context(), sourceRPC, programID, cloneAccounts, cloneIncludeProgData)
+		accountIDs, collectErr := collectAccountIDsForClone(cmd.Context(), client, programID, cloneAccounts, cloneIncludeProgData)
 		if collectErr != nil {
 			progress.Fail("Clone failed")
 			return collectErr
 		}
-		if len(accountIDs) > 0 {
+		var owned []solana.KeyedAccount
+		if cloneOwnedAccounts {
+			owned, err = fetchOwnedAccounts(cmd.Context(), client, programID, ownedFilter, cloneMaxAccounts)
+			if err != nil {
+				progress.Fail("Clone failed")
+				return err
+			}
+			progress.Detail(fmt.Sprintf("Found %d account(s) owned by %s", len(owned), programID))
+		}
+		if len(accountIDs) > 0 || len(owned) > 0 {
 			accountsDir := resolveCloneAccountsDir(projectDir, programID, cloneAccountsDir)
 			if err := os.MkdirAll(accountsDir, 0o755); err != nil {
 				return fmt.Errorf("create accounts directory: %w", err)
 			}
 
-			records := make([]accountSnapshotRecord, 0, len(accountIDs))
-			for _, accountID := range accountIDs {
+			records := make([]accountSnapshotRecord, 0, len(accountIDs)+len(owned))
+			seen := make(map[string]struct{}, len(accountIDs))
+			writeSnapshot := func(accountID string, account solana.Account) error {
 				out := filepath.Join(accountsDir, accountID+".json")
-				accountOutput, err := runSolana(
-					cmd.Context(),
-					"account",
-					"-u", sourceRPC,
-					"--output", "json-compact",
-					"--output-file", out,
-					accountID,
-				)
+				record, err := writeAccountSnapshot(accountID, account, out, mintOverride)
 				if err != nil {
-					progress.Fail("Clone failed")
-					return fmt.Errorf("dump account %s failed: %w\n%s", accountID, err, strings.TrimSpace(accountOutput))
+					return err
 				}
-				records = append(records, accountSnapshotRecord{
-					Address: accountID,
-					Path:    out,
-				})
 				progress.Detail("Account snapshot written: " + out)
+				if record.MintAuthorityRewritten {
+					progress.Detail("Mint authority rewritten: " + accountID)
+				}
+				records = append(records, record)
+				seen[accountID] = struct{}{}
+				return nil
+			}
+			for _, accountID := range accountIDs {
+				account, err := client.GetAccountInfo(cmd.Context(), accountID)
+				if err == nil && account == nil {
+					err = errors.New("account not found")
+				}
+				if err != nil {
+					progress.Fail("Clone failed")
+					return fmt.Errorf("dump account %s: %w", accountID, err)
+				}
+				if err := writeSnapshot(accountID, *account); err != nil {
+					progress.Fail("Clone failed")
+					return err
+				}
 			}
+			for _, keyed := range owned {
+				if _, ok := seen[keyed.Address]; ok {
+					continue
+				}
+				if err := writeSnapshot(keyed.Address, keyed.Account); err != nil {
+					progress.Fail("Clone failed")
+					return err
+				}
+			}
+			result.Accounts = records
 
 			if cloneWriteAcctFlags {
 				flagsPath, err := writeAccountFlagsFile(accountsDir, records)
@@ -133,16 +206,18 @@ var cloneCmd = &cobra.Command{
 					progress.Fail("Clone failed")
 					return err
 				}
+				result.AccountFlagsPath = flagsPath
 				progress.Detail("Validator account flags written: " + flagsPath)
 			}
 
-			if cloneDeploy {
-				progress.Detail("Account snapshots exported; load them with validator --account flags")
-			}
+			progress.Detail("Account snapshots exported; add `dir: " + accountsDir + "` under validator.accounts to load them at genesis")
 		}
 
 		if !cloneDeploy {
 			progress.Success("Clone complete")
+			if structuredOutput() {
+				return writeOutputDocument(out, "clone", result)
+			}
 			ui.Header(out, "Clone")
 			ui.Fields(out,
 				ui.Field{Label: "Program", Value: programID},
@@ -158,7 +233,12 @@ var cloneCmd = &cobra.Command{
 			return err
 		}
 
-		deployArgs := []string{"program", "deploy", "-u", targetRPC, outPath}
+		deployRPC := targetRPC
+		if strings.TrimSpace(cloneTargetRPC) == "" {
+			// Resolved from local state, so it is our own deployment.
+			deployRPC = authorizedRPCURL(targetRPC)
+		}
+		deployArgs := []string{"program", "deploy", "-u", deployRPC, outPath}
 		if keypair := strings.TrimSpace(cloneKeypairPath); keypair != "" {
 			deployArgs = append(deployArgs, "--keypair", keypair)
 		}
@@ -175,6 +255,11 @@ var cloneCmd = &cobra.Command{
 
 		programIDOut := parseDeployedProgramID(deployOutput)
 		progress.Success("Clone deploy complete")
+		if structuredOutput() {
+			result.TargetRPC = targetRPC
+			result.DeployedProgramID = programIDOut
+			return writeOutputDocument(out, "clone", result)
+		}
 		ui.Header(out, "Clone")
 		ui.Fields(out,
 			ui.Field{Label: "Program", Value: programID},
@@ -194,7 +279,7 @@ var cloneCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(cloneCmd)
 
-	cloneCmd.Flags().StringVar(&cloneSourceRPC, "source-rpc", defaultCloneSourceRPC, "Source RPC URL used by `solana program dump`")
+	cloneCmd.Flags().StringVar(&cloneSourceRPC, "source-rpc", defaultCloneSourceRPC, "Source RPC URL for the program binary and account snapshots")
 	cloneCmd.Flags().StringVar(&cloneOutPath, "out", "", "Output path for dumped program binary (.so)")
 	cloneCmd.Flags().BoolVar(&cloneOverwrite, "overwrite", false, "Overwrite output file if it exists")
 	cloneCmd.Flags().BoolVar(&cloneDeploy, "deploy", false, "Deploy cloned binary to a target validator after dump")
@@ -206,6 +291,12 @@ func init() {
 	cloneCmd.Flags().StringVar(&cloneAccountsDir, "accounts-dir", "", "Directory for dumped account snapshots")
 	cloneCmd.Flags().BoolVar(&cloneIncludeProgData, "include-programdata", true, "Also snapshot upgradeable programdata account when available")
 	cloneCmd.Flags().BoolVar(&cloneWriteAcctFlags, "write-account-flags", true, "Write helper flags file for `solana-test-validator --account ...`")
+	cloneCmd.Flags().BoolVar(&cloneOwnedAccounts, "owned-accounts", false, "Also snapshot accounts owned by the program (getProgramAccounts)")
+	cloneCmd.Flags().Uint64Var(&cloneFilterSize, "filter-size", 0, "Only snapshot owned accounts with this data size in bytes")
+	cloneCmd.Flags().StringArrayVar(&cloneFilterMemcmp, "filter-memcmp", nil, "Only snapshot owned accounts whose data matches offset:base58 (repeatable)")
+	cloneCmd.Flags().IntVar(&cloneMaxAccounts, "max-accounts", validator.DefaultMaxOwnedAccounts, "Fail when more owned accounts than this match the filters")
+	cloneCmd.Flags().StringVar(&cloneMintAuthority, "mint-authority", "", "Pubkey or keypair path written as the mint authority of snapshotted SPL Token/Token-2022 mints")
+	cloneCmd.Flags().StringVar(&cloneFreezeAuthority, "freeze-authority", "", "Pubkey or keypair path written as the freeze authority of snapshotted mints (requires --mint-authority)")
 }
 
 func resolveCloneOutputPath(projectDir, programID, configuredPath string) string {
@@ -262,11 +353,35 @@ func parseDeployedProgramID(output string) string {
 }
 
 type accountSnapshotRecord struct {
-	Address string
-	Path    string
+	Address                string `json:"address" yaml:"address"`
+	Path                   string `json:"path" yaml:"path"`
+	MintAuthorityRewritten bool   `json:"mint_authority_rewritten,omitempty" yaml:"mint_authority_rewritten,omitempty"`
 }
 
-func collectAccountIDsForClone(ctx context.Context, sourceRPC, programID string, configured []string, includeProgramData bool) ([]string, error) {
+// writeAccountSnapshot writes account to path in the format of
+// `solana account --output json-compact`. When mintOverride is set and the
+// account is a token mint, its authorities are rewritten first.
+func writeAccountSnapshot(accountID string, account solana.Account, path string, mintOverride *solana.MintAuthorityOverride) (accountSnapshotRecord, error) {
+	record := accountSnapshotRecord{Address: accountID, Path: path}
+	snapshot, err := solana.AccountSnapshotJSON(accountID, account)
+	if err != nil {
+		return record, fmt.Errorf("encode account %s: %w", accountID, err)
+	}
+	if mintOverride != nil {
+		rewritten, ok, err := solana.RewriteMintAuthorities(snapshot, *mintOverride)
+		if err != nil {
+			return record, fmt.Errorf("rewrite mint authority for %s: %w", accountID, err)
+		}
+		snapshot = rewritten
+		record.MintAuthorityRewritten = ok
+	}
+	if err := os.WriteFile(path, snapshot, 0o644); err != nil {
+		return record, fmt.Errorf("write account snapshot %s: %w", accountID, err)
+	}
+	return record, nil
+}
+
+func collectAccountIDsForClone(ctx context.Context, client *solana.Client, programID string, configured []string, includeProgramData bool) ([]string, error) {
 	seen := make(map[string]struct{})
 	accounts := make([]string, 0, len(configured)+1)
 	for _, raw := range configured {
@@ -285,9 +400,9 @@ func collectAccountIDsForClone(ctx context.Context, sourceRPC, programID string,
 	}
 
 	if includeProgramData {
-		progData, err := fetchProgramDataAddress(ctx, sourceRPC, programID)
+		progData, err := client.ProgramDataAddress(ctx, programID)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("resolve programdata account: %w", err)
 		}
 		if progData != "" {
 			if _, ok := seen[progData]; !ok {
@@ -299,26 +414,6 @@ func collectAccountIDsForClone(ctx context.Context, sourceRPC, programID string,
 	return accounts, nil
 }
 
-func fetchProgramDataAddress(ctx context.Context, sourceRPC, programID string) (string, error) {
-	output, err := runSolana(ctx, "program", "show", "-u", sourceRPC, "--output", "json", programID)
-	if err != nil {
-		return "", fmt.Errorf("solana program show failed while resolving programdata account: %w\n%s", err, strings.TrimSpace(output))
-	}
-
-	var decoded struct {
-		ProgramDataAddress string `json:"programdataAddress"`
-	}
-	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
-		return "", fmt.Errorf("decode program show output: %w", err)
-	}
-
-	addr := strings.TrimSpace(decoded.ProgramDataAddress)
-	if addr == "" || !base58AddressPattern.MatchString(addr) {
-		return "", nil
-	}
-	return addr, nil
-}
-
 func writeAccountFlagsFile(accountsDir string, records []accountSnapshotRecord) (string, error) {
 	if len(records) == 0 {
 		return "", nil
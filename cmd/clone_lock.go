@@ -0,0 +1,339 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/CharlieAIO/sol-cloud/internal/clonelock"
+	"github.com/CharlieAIO/sol-cloud/internal/solana"
+	"github.com/CharlieAIO/sol-cloud/internal/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	cloneLockManifest  string
+	cloneLockSourceRPC string
+)
+
+var cloneLockCmd = &cobra.Command{
+	Use:   "clone",
+	Short: "Pin cloned programs and accounts with a lockfile",
+	Long: "Snapshot the programs and accounts listed in " + clonelock.ManifestFileName + " and record them in " + clonelock.LockFileName + ".\n" +
+		"`deploy` loads pinned snapshots instead of cloning those addresses from a live cluster.",
+}
+
+var cloneSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Snapshot the clone manifest and write the lockfile",
+	Example: `  sol-cloud clone sync
+  sol-cloud clone sync --source-rpc https://api.devnet.solana.com`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("get working directory: %w", err)
+		}
+		manifestPath := strings.TrimSpace(cloneLockManifest)
+		if manifestPath == "" {
+			manifestPath = filepath.Join(projectDir, clonelock.ManifestFileName)
+		}
+		manifest, err := clonelock.LoadManifest(manifestPath)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("no clone manifest at %s; list programs and accounts there first", manifestPath)
+			}
+			return err
+		}
+		sourceRPC := firstNonEmpty(cloneLockSourceRPC, manifest.SourceRPC, viper.GetString("validator.clone_rpc_url"), defaultCloneSourceRPC)
+
+		out := cmd.OutOrStdout()
+		progress := ui.NewProgress(progressOutput(cmd), 2)
+		progress.Start("Fetching pinned accounts")
+		lock := clonelock.NewLockfile(filepath.Join(projectDir, clonelock.LockFileName), sourceRPC)
+		if err := syncCloneLock(cmd.Context(), projectDir, manifest, lock, progress); err != nil {
+			progress.Fail("Clone sync failed")
+			return err
+		}
+		progress.Step("Writing lockfile")
+		if err := lock.Write(); err != nil {
+			progress.Fail("Clone sync failed")
+			return err
+		}
+		progress.Success("Clone sync complete")
+
+		if structuredOutput() {
+			return writeOutputDocument(out, "clone_sync", cloneSyncDocument{
+				SourceRPC: sourceRPC,
+				Manifest:  manifestPath,
+				Lockfile:  lock.Path,
+				Entries:   lock.Entries,
+			})
+		}
+		ui.Header(out, "Clone Sync")
+		ui.Fields(out,
+			ui.Field{Label: "Source RPC", Value: sourceRPC},
+			ui.Field{Label: "Manifest", Value: manifestPath},
+			ui.Field{Label: "Lockfile", Value: lock.Path},
+			ui.Field{Label: "Pinned", Value: lock.Summary()},
+		)
+		return nil
+	},
+}
+
+var cloneVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Report drift between the lockfile and the source cluster",
+	Example: `  sol-cloud clone verify
+  sol-cloud clone verify --output json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("get working directory: %w", err)
+		}
+		path, ok := clonelock.FindLockfile(projectDir)
+		if !ok {
+			return fmt.Errorf("no %s in %s; run `sol-cloud clone sync` first", clonelock.LockFileName, projectDir)
+		}
+		lock, err := clonelock.LoadLockfile(path)
+		if err != nil {
+			return err
+		}
+		sourceRPC := firstNonEmpty(cloneLockSourceRPC, lock.SourceRPC, defaultCloneSourceRPC)
+
+		out := cmd.OutOrStdout()
+		progress := ui.NewProgress(progressOutput(cmd), 1)
+		progress.Start("Comparing pinned accounts with " + sourceRPC)
+		result, err := verifyCloneLock(cmd.Context(), sourceRPC, lock)
+		if err != nil {
+			progress.Fail("Clone verify failed")
+			return err
+		}
+		if result.Drifted > 0 {
+			progress.Fail(fmt.Sprintf("%d pinned account(s) drifted", result.Drifted))
+		} else {
+			progress.Success("Pinned accounts match the source cluster")
+		}
+
+		if structuredOutput() {
+			if err := writeOutputDocument(out, "clone_verify", result); err != nil {
+				return err
+			}
+		} else {
+			ui.Header(out, "Clone Verify")
+			ui.Fields(out,
+				ui.Field{Label: "Source RPC", Value: sourceRPC},
+				ui.Field{Label: "Lockfile", Value: lock.Path},
+				ui.Field{Label: "Cluster slot", Value: fmt.Sprintf("%d", result.Slot)},
+			)
+			fields := make([]ui.Field, 0, len(result.Entries))
+			for _, entry := range result.Entries {
+				fields = append(fields, ui.Field{Label: entry.Address, Value: entry.describe()})
+			}
+			ui.Fields(out, fields...)
+		}
+		if result.Drifted > 0 {
+			return fmt.Errorf("%d pinned account(s) drifted from %s; run `sol-cloud clone sync` to re-pin", result.Drifted, sourceRPC)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cloneLockCmd)
+	cloneLockCmd.AddCommand(cloneSyncCmd, cloneVerifyCmd)
+
+	cloneLockCmd.PersistentFlags().StringVar(&cloneLockSourceRPC, "source-rpc", "", "Source cluster RPC URL (default: manifest source_rpc, then validator.clone_rpc_url)")
+	cloneSyncCmd.Flags().StringVar(&cloneLockManifest, "manifest", "", "Clone manifest path (default: "+clonelock.ManifestFileName+" in the project directory)")
+}
+
+type cloneSyncDocument struct {
+	SourceRPC string            `json:"source_rpc" yaml:"source_rpc"`
+	Manifest  string            `json:"manifest" yaml:"manifest"`
+	Lockfile  string            `json:"lockfile" yaml:"lockfile"`
+	Entries   []clonelock.Entry `json:"entries" yaml:"entries"`
+}
+
+type cloneVerifyDocument struct {
+	SourceRPC string              `json:"source_rpc" yaml:"source_rpc"`
+	Slot      uint64              `json:"slot" yaml:"slot"`
+	Drifted   int                 `json:"drifted" yaml:"drifted"`
+	Entries   []cloneVerifyRecord `json:"entries" yaml:"entries"`
+}
+
+// Verify statuses.
+const (
+	cloneVerifyOK      = "ok"
+	cloneVerifyChanged = "changed"
+	cloneVerifyMissing = "missing"
+)
+
+type cloneVerifyRecord struct {
+	Address      string `json:"address" yaml:"address"`
+	Kind         string `json:"kind" yaml:"kind"`
+	Status       string `json:"status" yaml:"status"`
+	LockedSlot   uint64 `json:"locked_slot" yaml:"locked_slot"`
+	LockedOwner  string `json:"locked_owner" yaml:"locked_owner"`
+	Owner        string `json:"owner,omitempty" yaml:"owner,omitempty"`
+	LockedSHA256 string `json:"locked_sha256" yaml:"locked_sha256"`
+	SHA256       string `json:"sha256,omitempty" yaml:"sha256,omitempty"`
+}
+
+func (r cloneVerifyRecord) describe() string {
+	switch r.Status {
+	case cloneVerifyMissing:
+		return "missing on the source cluster"
+	case cloneVerifyChanged:
+		var changes []string
+		if r.Owner != r.LockedOwner {
+			changes = append(changes, fmt.Sprintf("owner %s -> %s", r.LockedOwner, r.Owner))
+		}
+		if r.SHA256 != r.LockedSHA256 {
+			changes = append(changes, fmt.Sprintf("data changed since slot %d", r.LockedSlot))
+		}
+		return "changed: " + strings.Join(changes, ", ")
+	default:
+		return r.Status
+	}
+}
+
+// syncCloneLock snapshots manifest into its snapshots dir and records each
+// account in lock. Programs are read first so that upgradeable programs can
+// pin their programdata account in the second batch.
+func syncCloneLock(ctx context.Context, projectDir string, manifest *clonelock.Manifest, lock *clonelock.Lockfile, progress *ui.Progress) error {
+	client := solana.NewClient(lock.SourceRPC)
+	snapshotsDir := manifest.SnapshotsDir
+	if !filepath.IsAbs(snapshotsDir) {
+		snapshotsDir = filepath.Join(projectDir, snapshotsDir)
+	}
+	if err := os.MkdirAll(snapshotsDir, 0o755); err != nil {
+		return fmt.Errorf("create snapshots directory: %w", err)
+	}
+
+	written := map[string]struct{}{}
+	pin := func(address, kind, program string, account *solana.Account, slot uint64) error {
+		if account == nil {
+			return fmt.Errorf("%s %s not found on %s", kind, address, lock.SourceRPC)
+		}
+		if _, ok := written[address]; ok {
+			return nil
+		}
+		path := filepath.Join(snapshotsDir, address+".json")
+		*account = solana.ResetProgramDataSlot(*account)
+		if _, err := writeAccountSnapshot(address, *account, path, nil); err != nil {
+			return err
+		}
+		if rel, err := filepath.Rel(projectDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+		written[address] = struct{}{}
+		lock.Entries = append(lock.Entries, clonelock.Entry{
+			Address: address,
+			Kind:    kind,
+			Program: program,
+			Owner:   account.Owner,
+			Slot:    slot,
+			SHA256:  clonelock.DataHash(account.Data),
+			Path:    filepath.ToSlash(path),
+		})
+		progress.Detail("Pinned " + kind + " " + address)
+		return nil
+	}
+
+	programs, slot, err := client.GetMultipleAccountsWithSlot(ctx, manifest.Programs)
+	if err != nil {
+		return fmt.Errorf("fetch programs: %w", err)
+	}
+	var secondBatch []string
+	programDataOf := map[string]string{}
+	for i, address := range manifest.Programs {
+		if programs[i] != nil && !programs[i].Executable {
+			return fmt.Errorf("program %s is not executable; list it under accounts instead", address)
+		}
+		if err := pin(address, clonelock.KindProgram, "", programs[i], slot); err != nil {
+			return err
+		}
+		if programData := programs[i].ProgramDataAddress(); programData != "" {
+			programDataOf[programData] = address
+			secondBatch = append(secondBatch, programData)
+		}
+	}
+	secondBatch = append(secondBatch, manifest.Accounts...)
+	accounts, slot, err := client.GetMultipleAccountsWithSlot(ctx, secondBatch)
+	if err != nil {
+		return fmt.Errorf("fetch accounts: %w", err)
+	}
+	for i, address := range secondBatch {
+		kind, program := clonelock.KindAccount, ""
+		if owner, ok := programDataOf[address]; ok {
+			kind, program = clonelock.KindProgramData, owner
+		}
+		if err := pin(address, kind, program, accounts[i], slot); err != nil {
+			return err
+		}
+	}
+
+	// Drop snapshots left over from addresses removed from the manifest.
+	entries, err := os.ReadDir(snapshotsDir)
+	if err != nil {
+		return fmt.Errorf("read snapshots directory: %w", err)
+	}
+	for _, entry := range entries {
+		address, ok := strings.CutSuffix(entry.Name(), ".json")
+		if entry.IsDir() || !ok || !base58AddressPattern.MatchString(address) {
+			continue
+		}
+		if _, keep := written[address]; keep {
+			continue
+		}
+		if err := os.Remove(filepath.Join(snapshotsDir, entry.Name())); err != nil {
+			return fmt.Errorf("remove stale snapshot: %w", err)
+		}
+	}
+	return nil
+}
+
+// verifyCloneLock compares every lockfile entry with the account on sourceRPC.
+func verifyCloneLock(ctx context.Context, sourceRPC string, lock *clonelock.Lockfile) (cloneVerifyDocument, error) {
+	result := cloneVerifyDocument{SourceRPC: sourceRPC, Entries: make([]cloneVerifyRecord, 0, len(lock.Entries))}
+	addresses := make([]string, 0, len(lock.Entries))
+	for _, entry := range lock.Entries {
+		addresses = append(addresses, entry.Address)
+	}
+	accounts, slot, err := solana.NewClient(sourceRPC).GetMultipleAccountsWithSlot(ctx, addresses)
+	if err != nil {
+		return result, fmt.Errorf("fetch pinned accounts: %w", err)
+	}
+	result.Slot = slot
+
+	for i, entry := range lock.Entries {
+		record := cloneVerifyRecord{
+			Address:      entry.Address,
+			Kind:         entry.Kind,
+			Status:       cloneVerifyOK,
+			LockedSlot:   entry.Slot,
+			LockedOwner:  entry.Owner,
+			LockedSHA256: entry.SHA256,
+		}
+		if account := accounts[i]; account == nil {
+			record.Status = cloneVerifyMissing
+		} else {
+			// Pinned programdata is stored with its deployment slot reset.
+			record.Owner = account.Owner
+			record.SHA256 = clonelock.DataHash(solana.ResetProgramDataSlot(*account).Data)
+			if record.Owner != entry.Owner || record.SHA256 != entry.SHA256 {
+				record.Status = cloneVerifyChanged
+			}
+		}
+		if record.Status != cloneVerifyOK {
+			result.Drifted++
+		}
+		result.Entries = append(result.Entries, record)
+	}
+	return result, nil
+}
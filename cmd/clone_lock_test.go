@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CharlieAIO/sol-cloud/internal/clonelock"
+	"github.com/CharlieAIO/sol-cloud/internal/solana"
+)
+
+func TestSyncCloneLockResetsProgramDataSlot(t *testing.T) {
+	const (
+		programID   = "Prog111111111111111111111111111111111111111"
+		programData = "ProgData11111111111111111111111111111111111"
+	)
+	programDataKey, err := solana.ParsePublicKey(programData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loader := solana.BPFLoaderUpgradeableID.String()
+	programAccount := append(binary.LittleEndian.AppendUint32(nil, 2), programDataKey[:]...)
+	programDataAccount := binary.LittleEndian.AppendUint32(nil, 3)
+	programDataAccount = binary.LittleEndian.AppendUint64(programDataAccount, 987654)
+	programDataAccount = append(append(programDataAccount, 1), bytes.Repeat([]byte{0xAA}, 32)...)
+	programDataAccount = append(programDataAccount, []byte("\x7fELF")...)
+	accounts := map[string]map[string]any{
+		programID:   {"lamports": 1, "data": []string{base64.StdEncoding.EncodeToString(programAccount), "base64"}, "owner": loader, "executable": true, "rentEpoch": 0, "space": len(programAccount)},
+		programData: {"lamports": 1, "data": []string{base64.StdEncoding.EncodeToString(programDataAccount), "base64"}, "owner": loader, "executable": false, "rentEpoch": 0, "space": len(programDataAccount)},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Method != "getMultipleAccounts" {
+			http.Error(w, "unsupported request", http.StatusBadRequest)
+			return
+		}
+		var addresses []string
+		_ = json.Unmarshal(req.Params[0], &addresses)
+		values := make([]any, 0, len(addresses))
+		for _, address := range addresses {
+			if account, ok := accounts[address]; ok {
+				values = append(values, account)
+			} else {
+				values = append(values, nil)
+			}
+		}
+		result := map[string]any{"context": map[string]any{"slot": 1000}, "value": values}
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "result": result})
+	}))
+	defer server.Close()
+
+	projectDir := t.TempDir()
+	manifest := &clonelock.Manifest{SnapshotsDir: "snapshots", Programs: []string{programID}}
+	lock := clonelock.NewLockfile(filepath.Join(projectDir, clonelock.LockFileName), server.URL)
+	if err := syncCloneLock(context.Background(), projectDir, manifest, lock, nil); err != nil {
+		t.Fatalf("syncCloneLock: %v", err)
+	}
+
+	snapshot, err := os.ReadFile(filepath.Join(projectDir, "snapshots", programData+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, account, err := solana.ParseAccountSnapshot(snapshot)
+	if err != nil {
+		t.Fatalf("ParseAccountSnapshot: %v", err)
+	}
+	if slot := binary.LittleEndian.Uint64(account.Data[4:12]); slot != 0 {
+		t.Errorf("programdata snapshot slot = %d, want 0", slot)
+	}
+	if !bytes.Equal(account.Data[12:], programDataAccount[12:]) {
+		t.Errorf("programdata snapshot changed beyond the slot")
+	}
+	if err := lock.Check(projectDir); err != nil {
+		t.Errorf("Check: %v", err)
+	}
+
+	verified, err := verifyCloneLock(context.Background(), server.URL, lock)
+	if err != nil {
+		t.Fatalf("verifyCloneLock: %v", err)
+	}
+	if verified.Drifted != 0 {
+		t.Errorf("verify reports drift for an unchanged cluster: %+v", verified.Entries)
+	}
+}
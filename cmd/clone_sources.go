@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/CharlieAIO/sol-cloud/internal/solana"
+	"github.com/CharlieAIO/sol-cloud/internal/validator"
+)
+
+// snapshotSourcedClones applies clone_programs entries that name their own
+// source. Entries from clone_rpc_url join the plain clone list. The rest are
+// read from their cluster, along with the programdata account of upgradeable
+// programs, written to .sol-cloud/sourced-clones, and added as a dir account
+// fixture. Cloned mints get the same authority rewrite as rewriteClonedMints.
+// It returns the number of accounts written.
+func snapshotSourcedClones(ctx context.Context, projectDir string, cfg *validator.Config) (int, error) {
+	dir := filepath.Join(projectDir, ".sol-cloud", "sourced-clones")
+	// Rebuild the directory so entries removed from the config do not linger.
+	if err := os.RemoveAll(dir); err != nil {
+		return 0, fmt.Errorf("clear sourced clones directory: %w", err)
+	}
+	if len(cfg.SourcedClonePrograms) == 0 {
+		return 0, nil
+	}
+
+	primary := validator.ClusterURL(cfg.CloneRPCURL)
+	var sources []string
+	bySource := map[string][]string{}
+	remaining := cfg.SourcedClonePrograms[:0:0]
+	for _, entry := range cfg.SourcedClonePrograms {
+		address := strings.TrimSpace(entry.Address)
+		source := validator.ClusterURL(entry.Source)
+		if source == "" || source == primary {
+			cfg.ClonePrograms = append(cfg.ClonePrograms, address)
+			continue
+		}
+		if _, ok := bySource[source]; !ok {
+			sources = append(sources, source)
+		}
+		bySource[source] = append(bySource[source], address)
+		remaining = append(remaining, entry)
+	}
+	cfg.SourcedClonePrograms = remaining
+	if len(remaining) == 0 {
+		return 0, nil
+	}
+
+	var mintOverride *solana.MintAuthorityOverride
+	if strings.TrimSpace(cfg.CloneMintAuthority) != "" {
+		override, err := loadMintAuthorityOverride(projectDir, cfg.CloneMintAuthority, cfg.CloneFreezeAuthority)
+		if err != nil {
+			return 0, fmt.Errorf("clone_mint_authority: %w", err)
+		}
+		mintOverride = &override
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, fmt.Errorf("create sourced clones directory: %w", err)
+	}
+
+	written := 0
+	for _, source := range sources {
+		addresses, accounts, err := fetchSourcedClones(ctx, solana.NewClient(source), bySource[source])
+		if err != nil {
+			return 0, fmt.Errorf("clone from %s: %w", source, err)
+		}
+		for i, address := range addresses {
+			if _, err := writeAccountSnapshot(address, *accounts[i], filepath.Join(dir, address+".json"), mintOverride); err != nil {
+				return 0, err
+			}
+			written++
+		}
+	}
+	cfg.Accounts = append(cfg.Accounts, validator.AccountFixture{Dir: dir})
+	return written, nil
+}
+
+// fetchSourcedClones reads addresses from client and appends the programdata
+// account of every upgradeable program among them, with its deployment slot
+// reset so the program is invocable when loaded at genesis. Missing accounts
+// are an error, matching solana-test-validator --clone.
+func fetchSourcedClones(ctx context.Context, client *solana.Client, addresses []string) ([]string, []*solana.Account, error) {
+	accounts, err := client.GetMultipleAccounts(ctx, addresses)
+	if err != nil {
+		return nil, nil, err
+	}
+	var programData []string
+	for i, account := range accounts {
+		if account == nil {
+			return nil, nil, fmt.Errorf("account %s not found", addresses[i])
+		}
+		if address := account.ProgramDataAddress(); address != "" {
+			programData = append(programData, address)
+		}
+	}
+	if len(programData) == 0 {
+		return addresses, accounts, nil
+	}
+	dataAccounts, err := client.GetMultipleAccounts(ctx, programData)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, account := range dataAccounts {
+		if account == nil {
+			return nil, nil, fmt.Errorf("programdata account %s not found", programData[i])
+		}
+		reset := solana.ResetProgramDataSlot(*account)
+		dataAccounts[i] = &reset
+	}
+	return append(append([]string(nil), addresses...), programData...), append(accounts, dataAccounts...), nil
+}
@@ -2,11 +2,14 @@ package cmd
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/CharlieAIO/sol-cloud/internal/anchor"
+	"github.com/CharlieAIO/sol-cloud/internal/clonelock"
 	appconfig "github.com/CharlieAIO/sol-cloud/internal/config"
 	"github.com/CharlieAIO/sol-cloud/internal/providers"
 	"github.com/CharlieAIO/sol-cloud/internal/ui"
@@ -36,10 +39,18 @@ var (
 	deployProgramIDKeypair   string
 	deployProgramIDLegacy    string
 	deployUpgradeAuthority   string
+	deployProgramMode        string
 	deployVolumeSize         int
 	deploySkipVolume         bool
+	deployHostPort           int
 	deployForceReset         bool
 	deployCloneRPCURL        string
+	deploySkipAnchor         bool
+	deploySkipCloneLock      bool
+	deployDeactivateFeatures []string
+	deployMatchCluster       string
+	deployCloneMintAuthority string
+	deployCloneFreezeAuth    string
 )
 
 var deployCmd = &cobra.Command{
@@ -74,8 +85,13 @@ var deployCmd = &cobra.Command{
 			if err != nil {
 				return fmt.Errorf("invalid app_name in project config: %w", err)
 			}
+		case "docker":
+			name, err = utils.EnsureDockerContainerName(name)
+			if err != nil {
+				return fmt.Errorf("invalid app_name in project config: %w", err)
+			}
 		default:
-			return fmt.Errorf("unsupported provider %q: valid providers are fly, railway", providerName)
+			return fmt.Errorf("unsupported provider %q: valid providers are fly, railway, docker", providerName)
 		}
 
 		region := strings.TrimSpace(deployRegion)
@@ -83,9 +99,12 @@ var deployCmd = &cobra.Command{
 			region = strings.TrimSpace(viper.GetString("region"))
 		}
 		if region == "" {
-			if providerName == "railway" {
+			switch providerName {
+			case "railway":
 				region = "us-west"
-			} else {
+			case "docker":
+				region = "local"
+			default:
 				region = "ord"
 			}
 		}
@@ -97,6 +116,30 @@ var deployCmd = &cobra.Command{
 
 		var airdropAccounts []validator.AirdropEntry
 		_ = viper.UnmarshalKey("validator.airdrop_accounts", &airdropAccounts)
+		programDeploys, err := loadProgramDeployConfigs()
+		if err != nil {
+			return err
+		}
+		clonePrograms, sourcedClones, err := loadClonePrograms()
+		if err != nil {
+			return err
+		}
+		var accountFixtures []validator.AccountFixture
+		if err := viper.UnmarshalKey("validator.accounts", &accountFixtures); err != nil {
+			return fmt.Errorf("invalid validator.accounts in project config: %w", err)
+		}
+		var ownedAccounts []validator.OwnedAccountsClone
+		if err := viper.UnmarshalKey("validator.clone_owned_accounts", &ownedAccounts); err != nil {
+			return fmt.Errorf("invalid validator.clone_owned_accounts in project config: %w", err)
+		}
+		var tokenFixtures []validator.TokenFixture
+		if err := viper.UnmarshalKey("validator.token_fixtures", &tokenFixtures); err != nil {
+			return fmt.Errorf("invalid validator.token_fixtures in project config: %w", err)
+		}
+		faucet, err := loadFaucetConfig()
+		if err != nil {
+			return err
+		}
 
 		validatorCfg := validator.Config{
 			SlotsPerEpoch:            viper.GetUint64("validator.slots_per_epoch"),
@@ -105,22 +148,33 @@ var deployCmd = &cobra.Command{
 			LedgerLimitSize:          viper.GetUint64("validator.ledger_limit_size"),
 			LedgerDiskLimitGB:        viper.GetInt("validator.ledger_disk_limit_gb"),
 			CloneRPCURL:              viper.GetString("validator.clone_rpc_url"),
-			ClonePrograms:            viper.GetStringSlice("validator.clone_programs"),
+			ClonePrograms:            clonePrograms,
+			SourcedClonePrograms:     sourcedClones,
 			CloneAccounts:            viper.GetStringSlice("validator.clone_accounts"),
 			CloneUpgradeablePrograms: viper.GetStringSlice("validator.clone_upgradeable_programs"),
+			CloneOwnedAccounts:       ownedAccounts,
+			CloneMintAuthority:       viper.GetString("validator.clone_mint_authority"),
+			CloneFreezeAuthority:     viper.GetString("validator.clone_freeze_authority"),
+			DeactivateFeatures:       viper.GetStringSlice("validator.deactivate_features"),
 			AirdropAccounts:          airdropAccounts,
+			Accounts:                 accountFixtures,
+			TokenFixtures:            tokenFixtures,
 			ForceReset:               viper.GetBool("validator.force_reset"),
-			ProgramDeploy: validator.ProgramDeployConfig{
-				SOPath:               viper.GetString("validator.program_deploy.so_path"),
-				ProgramIDKeypairPath: viper.GetString("validator.program_deploy.program_id_keypair"),
-				UpgradeAuthorityPath: viper.GetString("validator.program_deploy.upgrade_authority"),
-			},
-		}
-		// Backward compatibility for older configs that used program_id pubkey semantics.
-		if strings.TrimSpace(validatorCfg.ProgramDeploy.ProgramIDKeypairPath) == "" {
-			validatorCfg.ProgramDeploy.ProgramIDKeypairPath = viper.GetString("validator.program_deploy.program_id")
+			ProgramDeploys:           programDeploys,
+			Faucet:                   faucet,
 		}
 		validatorCfg.ApplyDefaults()
+		anchorSummary := ""
+		if !deploySkipAnchor {
+			anchorImport, err := loadAnchorImport(projectDir)
+			if err != nil {
+				return err
+			}
+			if anchorImport != nil {
+				anchorImport.ApplyTo(&validatorCfg)
+				anchorSummary = anchorImport.Summary()
+			}
+		}
 		if deploySlotsPerEpoch > 0 {
 			validatorCfg.SlotsPerEpoch = deploySlotsPerEpoch
 		}
@@ -138,6 +192,7 @@ var deployCmd = &cobra.Command{
 		}
 		if cmd.Flags().Changed("clone-program") {
 			validatorCfg.ClonePrograms = append([]string(nil), deployClonePrograms...)
+			validatorCfg.SourcedClonePrograms = nil
 		}
 		if cmd.Flags().Changed("clone") {
 			validatorCfg.CloneAccounts = append([]string(nil), deployCloneAccounts...)
@@ -145,17 +200,41 @@ var deployCmd = &cobra.Command{
 		if cmd.Flags().Changed("clone-upgradeable-program") {
 			validatorCfg.CloneUpgradeablePrograms = append([]string(nil), deployCloneUpPrograms...)
 		}
-		if cmd.Flags().Changed("program-so") {
-			validatorCfg.ProgramDeploy.SOPath = deployProgramSOPath
+		if cmd.Flags().Changed("deactivate-feature") {
+			validatorCfg.DeactivateFeatures = append([]string(nil), deployDeactivateFeatures...)
 		}
-		if cmd.Flags().Changed("program-id-keypair") {
-			validatorCfg.ProgramDeploy.ProgramIDKeypairPath = deployProgramIDKeypair
-		}
-		if cmd.Flags().Changed("program-id") {
-			validatorCfg.ProgramDeploy.ProgramIDKeypairPath = deployProgramIDLegacy
-		}
-		if cmd.Flags().Changed("upgrade-authority") {
-			validatorCfg.ProgramDeploy.UpgradeAuthorityPath = deployUpgradeAuthority
+		if cluster := strings.TrimSpace(deployMatchCluster); cluster != "" {
+			features, err := inactiveClusterFeatures(cmd.Context(), cluster)
+			if err != nil {
+				return err
+			}
+			validatorCfg.DeactivateFeatures = mergeAddresses(validatorCfg.DeactivateFeatures, features)
+		}
+		if cmd.Flags().Changed("program-so") || cmd.Flags().Changed("program-id-keypair") ||
+			cmd.Flags().Changed("program-id") || cmd.Flags().Changed("upgrade-authority") ||
+			cmd.Flags().Changed("program-mode") {
+			// Program flags describe a single deploy; they override a single
+			// configured entry field-by-field and replace a list outright.
+			var programDeploy validator.ProgramDeployConfig
+			if len(validatorCfg.ProgramDeploys) == 1 {
+				programDeploy = validatorCfg.ProgramDeploys[0]
+			}
+			if cmd.Flags().Changed("program-so") {
+				programDeploy.SOPath = deployProgramSOPath
+			}
+			if cmd.Flags().Changed("program-id-keypair") {
+				programDeploy.ProgramIDKeypairPath = deployProgramIDKeypair
+			}
+			if cmd.Flags().Changed("program-id") {
+				programDeploy.ProgramIDKeypairPath = deployProgramIDLegacy
+			}
+			if cmd.Flags().Changed("upgrade-authority") {
+				programDeploy.UpgradeAuthorityPath = deployUpgradeAuthority
+			}
+			if cmd.Flags().Changed("program-mode") {
+				programDeploy.Mode = deployProgramMode
+			}
+			validatorCfg.ProgramDeploys = []validator.ProgramDeployConfig{programDeploy}
 		}
 		if cmd.Flags().Changed("airdrop") {
 			parsed, parseErr := parseAirdropFlags(deployAirdropRaw)
@@ -170,27 +249,83 @@ var deployCmd = &cobra.Command{
 		if cmd.Flags().Changed("clone-rpc-url") {
 			validatorCfg.CloneRPCURL = deployCloneRPCURL
 		}
+		if cmd.Flags().Changed("clone-mint-authority") {
+			validatorCfg.CloneMintAuthority = deployCloneMintAuthority
+		}
+		if cmd.Flags().Changed("clone-freeze-authority") {
+			validatorCfg.CloneFreezeAuthority = deployCloneFreezeAuth
+		}
+		cloneLockSummary := ""
+		if !deploySkipCloneLock {
+			lock, err := clonelock.Load(projectDir)
+			if err != nil {
+				return err
+			}
+			if lock != nil {
+				lock.ApplyTo(&validatorCfg)
+				cloneLockSummary = lock.Summary()
+			}
+		}
 		if err := validatorCfg.Validate(); err != nil {
 			return fmt.Errorf("invalid validator config: %w", err)
 		}
+		ownedCount, err := snapshotOwnedAccounts(cmd.Context(), projectDir, &validatorCfg)
+		if err != nil {
+			return err
+		}
+		ownedSummary := ""
+		if len(validatorCfg.CloneOwnedAccounts) > 0 {
+			ownedSummary = fmt.Sprintf("%d account(s) owned by %d program(s) loaded from snapshots", ownedCount, len(validatorCfg.CloneOwnedAccounts))
+		}
+		sourcedCount, err := snapshotSourcedClones(cmd.Context(), projectDir, &validatorCfg)
+		if err != nil {
+			return err
+		}
+		sourcedSummary := ""
+		if sourcedCount > 0 {
+			sourcedSummary = fmt.Sprintf("%d account(s) cloned from other clusters and loaded from snapshots", sourcedCount)
+		}
+		rewrittenMints, err := rewriteClonedMints(cmd.Context(), projectDir, &validatorCfg)
+		if err != nil {
+			return err
+		}
+		mintSummary := ""
+		if len(rewrittenMints) > 0 {
+			mintSummary = fmt.Sprintf("%d cloned mint(s) loaded with rewritten authorities: %s", len(rewrittenMints), strings.Join(rewrittenMints, ", "))
+		}
+
+		accessCfg, err := loadAccessConfig()
+		if err != nil {
+			return err
+		}
+		if err := accessCfg.Validate(); err != nil {
+			return fmt.Errorf("invalid access config: %w", err)
+		}
 
 		volumeSize := deployVolumeSize
 		if volumeSize <= 0 {
 			volumeSize = 10
 		}
 
+		hostPort, err := resolveDockerHostPort(projectDir, providerName, name)
+		if err != nil {
+			return err
+		}
+
 		cfg := &providers.Config{
 			Name:                name,
 			OrgSlug:             firstNonEmpty(strings.TrimSpace(deployOrg), strings.TrimSpace(viper.GetString("org"))),
 			Region:              region,
 			ProjectDir:          projectDir,
 			Validator:           validatorCfg,
+			Access:              accessCfg,
 			DryRun:              deployDryRun,
 			SkipHealthCheck:     deploySkipHealthCheck,
 			HealthCheckTimeout:  deployHealthCheckTimeout,
 			HealthCheckInterval: deployHealthCheckPoll,
 			VolumeSize:          volumeSize,
 			SkipVolume:          deploySkipVolume,
+			HostPort:            hostPort,
 		}
 
 		provider, err := providers.NewProvider(providerName)
@@ -202,11 +337,14 @@ var deployCmd = &cobra.Command{
 		totalSteps := 2
 		if !deployDryRun {
 			totalSteps = 7
-			if providerName == "railway" {
+			switch providerName {
+			case "railway":
 				totalSteps = 8
+			case "docker":
+				totalSteps = 6
 			}
 		}
-		progress := ui.NewProgress(out, totalSteps)
+		progress := ui.NewProgress(progressOutput(cmd), totalSteps)
 		cfg.Reporter = progress
 		progress.Start("Preparing deploy")
 
@@ -242,6 +380,9 @@ var deployCmd = &cobra.Command{
 
 		if deployDryRun {
 			progress.Success("Dry run complete")
+			if structuredOutput() {
+				return writeOutputDocument(out, "deployment", toDeploymentDocument(deployment, region, "", true))
+			}
 			ui.Header(out, "Dry Run")
 			ui.Fields(out,
 				ui.Field{Label: "App", Value: deployment.Name},
@@ -250,14 +391,15 @@ var deployCmd = &cobra.Command{
 				ui.Field{Label: "RPC", Value: deployment.RPCURL},
 				ui.Field{Label: "WebSocket", Value: deployment.WebSocketURL},
 				ui.Field{Label: "Validator", Value: validatorSummary(validatorCfg)},
+				ui.Field{Label: "Anchor", Value: anchorSummary},
+				ui.Field{Label: "Clone lock", Value: cloneLockSummary},
+				ui.Field{Label: "Owned accounts", Value: ownedSummary},
+				ui.Field{Label: "Clone sources", Value: sourcedSummary},
+				ui.Field{Label: "Mints", Value: mintSummary},
+				ui.Field{Label: "Access", Value: accessSummary(accessCfg)},
+				ui.Field{Label: "Faucet", Value: faucetSummary(validatorCfg.Faucet, deployment.RPCURL)},
 			)
-			if validatorCfg.ProgramDeploy.Enabled() {
-				ui.Fields(out,
-					ui.Field{Label: "Program SO", Value: validatorCfg.ProgramDeploy.SOPath},
-					ui.Field{Label: "Program ID", Value: validatorCfg.ProgramDeploy.ProgramIDKeypairPath},
-					ui.Field{Label: "Authority", Value: validatorCfg.ProgramDeploy.UpgradeAuthorityPath},
-				)
-			}
+			ui.Fields(out, programDeployFields(validatorCfg.ProgramDeploys)...)
 			return nil
 		}
 
@@ -270,10 +412,16 @@ var deployCmd = &cobra.Command{
 			Region:       region,
 			ArtifactsDir: deployment.ArtifactsDir,
 			DashboardURL: deployment.DashboardURL,
+			HostPort:     deployment.HostPort,
+			VolumeSize:   volumeSize,
+			SkipVolume:   deploySkipVolume,
 		}); err != nil {
 			progress.Fail("Deploy failed")
 			return fmt.Errorf("update local deployment state: %w", err)
 		}
+		if validatorCfg.ForceReset {
+			state.ClearPrograms(deployment.Name)
+		}
 		if operation.ID != "" {
 			if err := state.FinishOperation(operation.ID, "succeeded", "deploy completed"); err != nil {
 				progress.Fail("Deploy failed")
@@ -286,6 +434,9 @@ var deployCmd = &cobra.Command{
 		}
 
 		progress.Success("Validator deployed")
+		if structuredOutput() {
+			return writeOutputDocument(out, "deployment", toDeploymentDocument(deployment, region, appconfig.StateFilePath(projectDir), false))
+		}
 		ui.Header(out, "Deployment")
 		ui.Fields(out,
 			ui.Field{Label: "App", Value: deployment.Name},
@@ -296,15 +447,16 @@ var deployCmd = &cobra.Command{
 			ui.Field{Label: "Artifacts", Value: deployment.ArtifactsDir},
 			ui.Field{Label: "State", Value: appconfig.StateFilePath(projectDir)},
 			ui.Field{Label: "Validator", Value: validatorSummary(validatorCfg)},
-			ui.Field{Label: "Solana CLI", Value: fmt.Sprintf("solana config set --url %s", deployment.RPCURL)},
+			ui.Field{Label: "Anchor", Value: anchorSummary},
+			ui.Field{Label: "Clone lock", Value: cloneLockSummary},
+			ui.Field{Label: "Owned accounts", Value: ownedSummary},
+			ui.Field{Label: "Clone sources", Value: sourcedSummary},
+			ui.Field{Label: "Mints", Value: mintSummary},
+			ui.Field{Label: "Access", Value: accessSummary(accessCfg)},
+			ui.Field{Label: "Faucet", Value: faucetSummary(validatorCfg.Faucet, deployment.RPCURL)},
+			ui.Field{Label: "Solana CLI", Value: fmt.Sprintf("solana config set --url %s", accessCfg.AuthorizeURL(deployment.RPCURL))},
 		)
-		if validatorCfg.ProgramDeploy.Enabled() {
-			ui.Fields(out,
-				ui.Field{Label: "Program SO", Value: validatorCfg.ProgramDeploy.SOPath},
-				ui.Field{Label: "Program ID", Value: validatorCfg.ProgramDeploy.ProgramIDKeypairPath},
-				ui.Field{Label: "Authority", Value: validatorCfg.ProgramDeploy.UpgradeAuthorityPath},
-			)
-		}
+		ui.Fields(out, programDeployFields(validatorCfg.ProgramDeploys)...)
 		return nil
 	},
 }
@@ -312,7 +464,7 @@ var deployCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(deployCmd)
 
-	deployCmd.Flags().StringVar(&deployRegion, "region", "", "Fly region (overrides region in config)")
+	deployCmd.Flags().StringVar(&deployRegion, "region", "", "Provider region (overrides region in config; ignored by docker)")
 	deployCmd.Flags().StringVar(&deployOrg, "org", "", "Org/team identifier (fly: org slug; railway: team id)")
 	deployCmd.Flags().BoolVar(&deployDryRun, "dry-run", false, "render files but skip API deployment")
 	deployCmd.Flags().BoolVar(&deploySkipHealthCheck, "skip-health-check", false, "skip post-deploy RPC health validation")
@@ -331,11 +483,19 @@ func init() {
 	deployCmd.Flags().StringVar(&deployProgramIDLegacy, "program-id", "", "deprecated alias for --program-id-keypair")
 	_ = deployCmd.Flags().MarkDeprecated("program-id", "use --program-id-keypair with a keypair path")
 	deployCmd.Flags().StringVar(&deployUpgradeAuthority, "upgrade-authority", "", "path to upgrade authority keypair (overrides validator.program_deploy.upgrade_authority)")
+	deployCmd.Flags().StringVar(&deployProgramMode, "program-mode", "", "startup program mode: runtime (deploy after boot) or genesis (load at slot 0)")
 	deployCmd.Flags().IntVar(&deployVolumeSize, "volume-size", 10, "size of persistent ledger volume in GB")
+	deployCmd.Flags().IntVar(&deployHostPort, "host-port", 0, "docker: localhost port for the RPC (overrides docker.host_port; default keeps the deployment's port, else 8080 or any free port)")
 	deployCmd.Flags().BoolVar(&deploySkipVolume, "skip-volume", false, "skip volume creation, use ephemeral storage (data loss on restart)")
 	deployCmd.Flags().BoolVarP(&deployForceReset, "reset", "r", false, "wipe the existing ledger on startup so --clone and other args take effect")
 	deployCmd.Flags().StringArrayVar(&deployAirdropRaw, "airdrop", nil, `airdrop SOL on startup; format: ADDRESS or ADDRESS:AMOUNT (default amount: 1000); repeatable`)
 	deployCmd.Flags().StringVar(&deployCloneRPCURL, "clone-rpc-url", "", "RPC endpoint for --clone fetches (default: mainnet-beta; use a private endpoint if rate-limited)")
+	deployCmd.Flags().StringVar(&deployCloneMintAuthority, "clone-mint-authority", "", "pubkey or keypair path that becomes the mint authority of cloned token mints (overrides validator.clone_mint_authority)")
+	deployCmd.Flags().StringVar(&deployCloneFreezeAuth, "clone-freeze-authority", "", "pubkey or keypair path that becomes the freeze authority of cloned token mints (overrides validator.clone_freeze_authority)")
+	deployCmd.Flags().StringSliceVar(&deployDeactivateFeatures, "deactivate-feature", nil, "feature gate pubkey(s) to deactivate at genesis (overrides validator.deactivate_features)")
+	deployCmd.Flags().StringVar(&deployMatchCluster, "match-cluster", "", "deactivate every feature not active on this cluster (mainnet or devnet), read over RPC")
+	deployCmd.Flags().BoolVar(&deploySkipAnchor, "skip-anchor", false, "ignore Anchor.toml in the project directory")
+	deployCmd.Flags().BoolVar(&deploySkipCloneLock, "skip-clone-lock", false, "ignore sol-cloud.clones.lock and clone pinned addresses live")
 }
 
 func firstNonEmpty(values ...string) string {
@@ -348,7 +508,7 @@ func firstNonEmpty(values ...string) string {
 }
 
 func validatorSummary(cfg validator.Config) string {
-	return fmt.Sprintf("slots_per_epoch=%d ticks_per_slot=%d compute_unit_limit=%d ledger_limit_size=%d ledger_disk_limit_gb=%d clone_programs=%d clone=%d clone_upgradeable_program=%d",
+	return fmt.Sprintf("slots_per_epoch=%d ticks_per_slot=%d compute_unit_limit=%d ledger_limit_size=%d ledger_disk_limit_gb=%d clone_programs=%d clone=%d clone_upgradeable_program=%d accounts=%d token_fixtures=%d deactivate_features=%d",
 		cfg.SlotsPerEpoch,
 		cfg.TicksPerSlot,
 		cfg.ComputeUnitLimit,
@@ -357,9 +517,130 @@ func validatorSummary(cfg validator.Config) string {
 		len(cfg.ClonePrograms),
 		len(cfg.CloneAccounts),
 		len(cfg.CloneUpgradeablePrograms),
+		len(cfg.Accounts),
+		len(cfg.TokenFixtures),
+		len(cfg.DeactivateFeatures),
 	)
 }
 
+// loadProgramDeployConfigs reads validator.program_deploy as either a list of
+// entries or the older single-object form. Entries with no fields set are dropped.
+func loadProgramDeployConfigs() ([]validator.ProgramDeployConfig, error) {
+	var entries []validator.ProgramDeployConfig
+	switch viper.Get("validator.program_deploy").(type) {
+	case nil:
+		return nil, nil
+	case []any:
+		if err := viper.UnmarshalKey("validator.program_deploy", &entries); err != nil {
+			return nil, fmt.Errorf("invalid validator.program_deploy in project config: %w", err)
+		}
+	default:
+		entry := validator.ProgramDeployConfig{
+			SOPath:               viper.GetString("validator.program_deploy.so_path"),
+			ProgramIDKeypairPath: viper.GetString("validator.program_deploy.program_id_keypair"),
+			UpgradeAuthorityPath: viper.GetString("validator.program_deploy.upgrade_authority"),
+			Mode:                 viper.GetString("validator.program_deploy.mode"),
+		}
+		// Backward compatibility for older configs that used program_id pubkey semantics.
+		if strings.TrimSpace(entry.ProgramIDKeypairPath) == "" {
+			entry.ProgramIDKeypairPath = viper.GetString("validator.program_deploy.program_id")
+		}
+		entries = []validator.ProgramDeployConfig{entry}
+	}
+
+	out := make([]validator.ProgramDeployConfig, 0, len(entries))
+	for _, entry := range entries {
+		if entry.HasValues() {
+			out = append(out, entry)
+		}
+	}
+	return out, nil
+}
+
+// loadClonePrograms reads validator.clone_programs, whose entries are either
+// addresses or {address, source} objects. Objects without a source are
+// returned with the plain addresses.
+func loadClonePrograms() ([]string, []validator.CloneProgram, error) {
+	items, ok := viper.Get("validator.clone_programs").([]any)
+	if !ok {
+		return viper.GetStringSlice("validator.clone_programs"), nil, nil
+	}
+	plain := make([]string, 0, len(items))
+	var sourced []validator.CloneProgram
+	for i, item := range items {
+		switch v := item.(type) {
+		case string:
+			plain = append(plain, v)
+		case map[string]any:
+			address, _ := v["address"].(string)
+			source, _ := v["source"].(string)
+			if strings.TrimSpace(source) == "" {
+				plain = append(plain, address)
+				continue
+			}
+			sourced = append(sourced, validator.CloneProgram{
+				Address: strings.TrimSpace(address),
+				Source:  strings.TrimSpace(source),
+			})
+		default:
+			return nil, nil, fmt.Errorf("invalid validator.clone_programs[%d] in project config: expected an address or an {address, source} object", i)
+		}
+	}
+	return plain, sourced, nil
+}
+
+// cloneProgramsConfigValue is the inverse of loadClonePrograms, for writing
+// validator.clone_programs back to the project config.
+func cloneProgramsConfigValue(plain []string, sourced []validator.CloneProgram) []any {
+	out := make([]any, 0, len(plain)+len(sourced))
+	for _, address := range plain {
+		out = append(out, address)
+	}
+	for _, entry := range sourced {
+		out = append(out, entry)
+	}
+	return out
+}
+
+// loadAnchorImport reads Anchor.toml from projectDir. It returns nil when the
+// project is not an Anchor workspace.
+func loadAnchorImport(projectDir string) (*anchor.Import, error) {
+	path, ok := anchor.Find(projectDir)
+	if !ok {
+		return nil, nil
+	}
+	workspace, err := anchor.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	imported, err := workspace.Import()
+	if err != nil {
+		return nil, err
+	}
+	return &imported, nil
+}
+
+// programDeployFields renders startup program deploys for summaries. Labels are
+// numbered only when more than one program is configured.
+func programDeployFields(entries []validator.ProgramDeployConfig) []ui.Field {
+	fields := make([]ui.Field, 0, len(entries)*3)
+	for i, entry := range entries {
+		suffix := ""
+		if len(entries) > 1 {
+			suffix = fmt.Sprintf(" %d", i+1)
+		}
+		fields = append(fields,
+			ui.Field{Label: "Program SO" + suffix, Value: entry.SOPath},
+			ui.Field{Label: "Program ID" + suffix, Value: entry.ProgramIDKeypairPath},
+			ui.Field{Label: "Authority" + suffix, Value: entry.UpgradeAuthorityPath},
+		)
+		if entry.IsGenesis() {
+			fields = append(fields, ui.Field{Label: "Program mode" + suffix, Value: validator.ProgramDeployModeGenesis})
+		}
+	}
+	return fields
+}
+
 // parseAirdropFlags converts raw --airdrop flag values ("ADDRESS" or "ADDRESS:AMOUNT")
 // into validator.AirdropEntry slice. A missing amount defaults to validator.DefaultAirdropAmount.
 func parseAirdropFlags(raw []string) ([]validator.AirdropEntry, error) {
@@ -386,3 +667,44 @@ func parseAirdropFlags(raw []string) ([]validator.AirdropEntry, error) {
 	}
 	return entries, nil
 }
+
+// resolveDockerHostPort picks the host port for a docker deploy: --host-port,
+// then docker.host_port, then the port the deployment already uses. Zero lets
+// the provider choose.
+func resolveDockerHostPort(projectDir, providerName, name string) (int, error) {
+	if providerName != "docker" {
+		return 0, nil
+	}
+	port := deployHostPort
+	if port == 0 {
+		port = viper.GetInt("docker.host_port")
+	}
+	if port < 0 || port > 65535 {
+		return 0, fmt.Errorf("invalid docker host port %d", port)
+	}
+	if port > 0 {
+		return port, nil
+	}
+	state, err := appconfig.LoadState(projectDir)
+	if err != nil {
+		return 0, fmt.Errorf("load local deployment state: %w", err)
+	}
+	if record, ok := state.Deployments[name]; ok && record.Provider == "docker" {
+		return recordHostPort(record), nil
+	}
+	return 0, nil
+}
+
+// recordHostPort returns the host port of a docker record, reading it from the
+// RPC URL for records saved before host_port was stored.
+func recordHostPort(record appconfig.DeploymentRecord) int {
+	if record.HostPort > 0 || record.Provider != "docker" {
+		return record.HostPort
+	}
+	parsed, err := url.Parse(record.RPCURL)
+	if err != nil {
+		return 0
+	}
+	port, _ := strconv.Atoi(parsed.Port())
+	return port
+}
@@ -72,7 +72,7 @@ var destroyCmd = &cobra.Command{
 				return confirmErr
 			}
 			if !confirmed {
-				fmt.Fprintln(cmd.OutOrStdout(), "destroy cancelled")
+				fmt.Fprintln(progressOutput(cmd), "destroy cancelled")
 				return nil
 			}
 		}
@@ -82,7 +82,7 @@ var destroyCmd = &cobra.Command{
 			return err
 		}
 		out := cmd.OutOrStdout()
-		progress := ui.NewProgress(out, 3)
+		progress := ui.NewProgress(progressOutput(cmd), 3)
 		progress.Start("Destroying cloud resources")
 		if err := provider.Destroy(cmd.Context(), name); err != nil {
 			progress.Fail("Destroy failed")
@@ -97,6 +97,14 @@ var destroyCmd = &cobra.Command{
 		}
 
 		progress.Success("Validator destroyed")
+		if structuredOutput() {
+			return writeOutputDocument(out, "destroy", destroyDocument{
+				Name:      name,
+				Provider:  providerName,
+				Destroyed: true,
+				StateFile: appconfig.StateFilePath(projectDir),
+			})
+		}
 		ui.Header(out, "Destroyed")
 		ui.Fields(out,
 			ui.Field{Label: "Validator", Value: name},
@@ -115,7 +123,7 @@ func init() {
 }
 
 func confirmDestroy(cmd *cobra.Command, name string) (bool, error) {
-	fmt.Fprintf(cmd.OutOrStdout(), "this will destroy '%s'. continue? (y/N): ", name)
+	fmt.Fprintf(progressOutput(cmd), "this will destroy '%s'. continue? (y/N): ", name)
 	reader := bufio.NewReader(cmd.InOrStdin())
 	line, err := reader.ReadString('\n')
 	if err != nil && !errors.Is(err, io.EOF) {
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/CharlieAIO/sol-cloud/internal/solana"
+	"github.com/CharlieAIO/sol-cloud/internal/validator"
+)
+
+// featureProgramID owns every feature gate account.
+const featureProgramID = "Feature111111111111111111111111111111111111"
+
+var matchClusterURLs = map[string]string{
+	"mainnet": validator.DefaultCloneRPCURL,
+	"devnet":  "https://api.devnet.solana.com",
+}
+
+// featureDiscoveryURLs are clusters that get feature gates before mainnet.
+// Their feature accounts, together with the matched cluster's, are the known
+// features checked by --match-cluster.
+var featureDiscoveryURLs = []string{
+	"https://api.testnet.solana.com",
+	"https://api.devnet.solana.com",
+}
+
+// inactiveClusterFeatures returns the known feature gates that are not active
+// on cluster. A feature is active when its account exists and holds
+// Some(activated_at); missing accounts and pending (None) ones are inactive.
+func inactiveClusterFeatures(ctx context.Context, cluster string) ([]string, error) {
+	cluster = strings.ToLower(strings.TrimSpace(cluster))
+	url, ok := matchClusterURLs[cluster]
+	if !ok {
+		return nil, fmt.Errorf("unsupported --match-cluster %q: use mainnet or devnet", cluster)
+	}
+
+	known, err := knownFeatureIDs(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := solana.NewClient(url).GetMultipleAccounts(ctx, known)
+	if err != nil {
+		return nil, fmt.Errorf("read %s feature accounts: %w", cluster, err)
+	}
+	var inactive []string
+	for i, account := range accounts {
+		if !featureActive(account) {
+			inactive = append(inactive, known[i])
+		}
+	}
+	return inactive, nil
+}
+
+// knownFeatureIDs lists the feature accounts on clusterURL and the discovery
+// clusters, sorted and without duplicates.
+func knownFeatureIDs(ctx context.Context, clusterURL string) ([]string, error) {
+	seen := map[string]struct{}{}
+	queried := map[string]struct{}{}
+	var ids []string
+	for _, rpcURL := range append([]string{clusterURL}, featureDiscoveryURLs...) {
+		if _, ok := queried[rpcURL]; ok {
+			continue
+		}
+		queried[rpcURL] = struct{}{}
+		addresses, err := solana.NewClient(rpcURL).GetProgramAccountKeys(ctx, featureProgramID, solana.ProgramAccountsFilter{})
+		if err != nil {
+			return nil, fmt.Errorf("list feature accounts on %s: %w", rpcURL, err)
+		}
+		for _, address := range addresses {
+			if _, ok := seen[address]; ok {
+				continue
+			}
+			seen[address] = struct{}{}
+			ids = append(ids, address)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// featureActive reports whether account is a feature gate whose bincode
+// Option<u64> activation slot is set.
+func featureActive(account *solana.Account) bool {
+	return account != nil && account.Owner == featureProgramID && len(account.Data) >= 9 && account.Data[0] == 1
+}
+
+// mergeAddresses appends values missing from list, keeping the existing order.
+func mergeAddresses(list, values []string) []string {
+	seen := make(map[string]struct{}, len(list))
+	for _, value := range list {
+		seen[strings.TrimSpace(value)] = struct{}{}
+	}
+	for _, value := range values {
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		list = append(list, value)
+	}
+	return list
+}
@@ -7,8 +7,10 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/CharlieAIO/sol-cloud/internal/anchor"
 	appconfig "github.com/CharlieAIO/sol-cloud/internal/config"
 	"github.com/CharlieAIO/sol-cloud/internal/ui"
 	"github.com/CharlieAIO/sol-cloud/internal/utils"
@@ -21,11 +23,13 @@ var (
 	initForce    bool
 	initYes      bool
 	initProvider string
+	initAnchor   bool
 )
 
 var providerOptions = []utils.Option{
 	{Key: "fly", Label: "Fly.io"},
 	{Key: "railway", Label: "Railway"},
+	{Key: "docker", Label: "Local Docker"},
 }
 
 var railwayRegionOptions = []utils.Option{
@@ -71,7 +75,8 @@ var initCmd = &cobra.Command{
 	Example: `  sol-cloud init
   sol-cloud init --force
   sol-cloud init --yes
-  sol-cloud init --yes --provider railway`,
+  sol-cloud init --yes --provider railway
+  sol-cloud init --from-anchor`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		out := cmd.OutOrStdout()
 		reader := bufio.NewReader(cmd.InOrStdin())
@@ -85,7 +90,18 @@ var initCmd = &cobra.Command{
 			return fmt.Errorf("resolve project config path: %w", err)
 		}
 
+		anchorImport, err := loadAnchorImport(projectDir)
+		if err != nil {
+			return err
+		}
+		if initAnchor && anchorImport == nil {
+			return fmt.Errorf("--from-anchor: no %s found in %s", anchor.FileName, projectDir)
+		}
+
 		if _, err := os.Stat(file); err == nil {
+			if initAnchor && !initForce {
+				return refreshConfigFromAnchor(out, file, *anchorImport)
+			}
 			if !initForce && !initYes {
 				overwrite, promptErr := utils.YesNo(reader, out, "Hidden project config already exists. Overwrite it?", false)
 				if promptErr != nil {
@@ -104,8 +120,8 @@ var initCmd = &cobra.Command{
 			if providerName == "" {
 				providerName = "fly"
 			}
-			if providerName != "fly" && providerName != "railway" {
-				return fmt.Errorf("unsupported provider %q: valid providers are fly, railway", providerName)
+			if providerName != "fly" && providerName != "railway" && providerName != "docker" {
+				return fmt.Errorf("unsupported provider %q: valid providers are fly, railway, docker", providerName)
 			}
 
 			var appName, region string
@@ -114,6 +130,9 @@ var initCmd = &cobra.Command{
 			case "railway":
 				appName, genErr = utils.GenerateRailwayProjectName()
 				region = "us-west"
+			case "docker":
+				appName, genErr = utils.GenerateFlyAppName()
+				region = "local"
 			default:
 				appName, genErr = utils.GenerateFlyAppName()
 				region = "ord"
@@ -123,6 +142,9 @@ var initCmd = &cobra.Command{
 			}
 
 			cfg := validator.DefaultConfig()
+			if anchorImport != nil {
+				anchorImport.ApplyTo(&cfg)
+			}
 			return writeInitConfig(out, file, providerName, appName, region, cfg)
 		}
 
@@ -135,7 +157,7 @@ var initCmd = &cobra.Command{
 			providerName, err = utils.SelectOptionArrow(cmd.InOrStdin(), out, "Provider", providerOptions, "fly")
 			if err != nil {
 				// Fall back to prompt if terminal is not interactive.
-				providerName, err = utils.String(reader, out, "Provider (fly or railway)", "fly", true)
+				providerName, err = utils.String(reader, out, "Provider (fly, railway, or docker)", "fly", true)
 				if err != nil {
 					return err
 				}
@@ -156,6 +178,13 @@ var initCmd = &cobra.Command{
 			if err != nil {
 				return err
 			}
+		case "docker":
+			appName, err = utils.GenerateFlyAppName()
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Docker container name: %s\n", appName)
+			region = "local"
 		default:
 			providerName = "fly"
 			appName, err = utils.GenerateFlyAppName()
@@ -227,10 +256,23 @@ var initCmd = &cobra.Command{
 			if promptErr != nil {
 				return promptErr
 			}
-			cfg.ProgramDeploy = validator.ProgramDeployConfig{
+			cfg.ProgramDeploys = []validator.ProgramDeployConfig{{
 				SOPath:               soPath,
 				ProgramIDKeypairPath: programIDKeypair,
 				UpgradeAuthorityPath: upgradeAuthorityPath,
+			}}
+		}
+
+		if anchorImport != nil {
+			importAnchor := initAnchor
+			if !importAnchor {
+				importAnchor, err = utils.YesNo(reader, out, fmt.Sprintf("Import programs, clones, and accounts from %s (%s)?", anchor.FileName, anchorImport.Summary()), true)
+				if err != nil {
+					return err
+				}
+			}
+			if importAnchor {
+				anchorImport.ApplyTo(&cfg)
 			}
 		}
 
@@ -248,9 +290,10 @@ func writeInitConfig(out interface{ Write([]byte) (int, error) }, file, provider
 	escapedRegion := strings.ReplaceAll(region, `"`, `\"`)
 	cloneProgramsYAML := renderYAMLStringList(cfg.ClonePrograms, "    ")
 	airdropYAML := renderYAMLAirdropList(cfg.AirdropAccounts, "    ")
-	escapedSOPath := strings.ReplaceAll(cfg.ProgramDeploy.SOPath, `"`, `\"`)
-	escapedProgramIDKeypair := strings.ReplaceAll(cfg.ProgramDeploy.ProgramIDKeypairPath, `"`, `\"`)
-	escapedUpgradeAuth := strings.ReplaceAll(cfg.ProgramDeploy.UpgradeAuthorityPath, `"`, `\"`)
+	programDeployYAML := renderYAMLProgramDeploy(cfg.ProgramDeploys, "    ")
+	accountsYAML := renderYAMLAccountFixtures(cfg.Accounts, "    ")
+	deactivateFeaturesYAML := renderYAMLStringList(cfg.DeactivateFeatures, "    ")
+	escapedCloneRPCURL := strings.ReplaceAll(cfg.CloneRPCURL, `"`, `\"`)
 
 	content := fmt.Sprintf(`provider: %s
 app_name: "%s"
@@ -261,15 +304,18 @@ validator:
   compute_unit_limit: %d
   ledger_limit_size: %d
   ledger_disk_limit_gb: %d
+  clone_rpc_url: "%s"
   clone_programs:
 %s
   airdrop_accounts:
+%s
+  accounts:
+%s
+  deactivate_features:
 %s
   program_deploy:
-    so_path: "%s"
-    program_id_keypair: "%s"
-    upgrade_authority: "%s"
-`, providerName, escapedAppName, escapedRegion, cfg.SlotsPerEpoch, cfg.TicksPerSlot, cfg.ComputeUnitLimit, cfg.LedgerLimitSize, cfg.LedgerDiskLimitGB, cloneProgramsYAML, airdropYAML, escapedSOPath, escapedProgramIDKeypair, escapedUpgradeAuth)
+%s
+`, providerName, escapedAppName, escapedRegion, cfg.SlotsPerEpoch, cfg.TicksPerSlot, cfg.ComputeUnitLimit, cfg.LedgerLimitSize, cfg.LedgerDiskLimitGB, escapedCloneRPCURL, cloneProgramsYAML, airdropYAML, accountsYAML, deactivateFeaturesYAML, programDeployYAML)
 
 	if err := os.MkdirAll(filepath.Dir(file), 0o700); err != nil {
 		return fmt.Errorf("create project config directory: %w", err)
@@ -297,7 +343,8 @@ func init() {
 
 	initCmd.Flags().BoolVar(&initForce, "force", false, "Overwrite hidden project config if it already exists")
 	initCmd.Flags().BoolVarP(&initYes, "yes", "y", false, "Skip all prompts; write config with generated app name and validator defaults")
-	initCmd.Flags().StringVar(&initProvider, "provider", "", "Provider to use (fly or railway); defaults to fly when --yes is set")
+	initCmd.Flags().BoolVar(&initAnchor, "from-anchor", false, "Import programs, clones, and accounts from Anchor.toml; refreshes an existing project config in place")
+	initCmd.Flags().StringVar(&initProvider, "provider", "", "Provider to use (fly, railway, or docker); defaults to fly when --yes is set")
 }
 
 func promptFlyRegion(in io.Reader, reader *bufio.Reader, out io.Writer, defaultRegion string) (string, error) {
@@ -384,6 +431,151 @@ func renderYAMLStringList(values []string, indent string) string {
 	return strings.Join(lines, "\n")
 }
 
+// renderYAMLProgramDeploy writes zero or one program as the single-object form
+// older configs use, and several programs as a list.
+func renderYAMLProgramDeploy(entries []validator.ProgramDeployConfig, indent string) string {
+	escape := func(value string) string {
+		return strings.ReplaceAll(value, `"`, `\"`)
+	}
+	if len(entries) <= 1 {
+		var entry validator.ProgramDeployConfig
+		if len(entries) == 1 {
+			entry = entries[0]
+		}
+		lines := []string{
+			fmt.Sprintf("%sso_path: \"%s\"", indent, escape(entry.SOPath)),
+			fmt.Sprintf("%sprogram_id_keypair: \"%s\"", indent, escape(entry.ProgramIDKeypairPath)),
+			fmt.Sprintf("%supgrade_authority: \"%s\"", indent, escape(entry.UpgradeAuthorityPath)),
+		}
+		if mode := strings.TrimSpace(entry.Mode); mode != "" {
+			lines = append(lines, fmt.Sprintf("%smode: \"%s\"", indent, escape(mode)))
+		}
+		return strings.Join(lines, "\n")
+	}
+	lines := make([]string, 0, len(entries)*4)
+	for _, entry := range entries {
+		lines = append(lines,
+			fmt.Sprintf("%s- so_path: \"%s\"", indent, escape(entry.SOPath)),
+			fmt.Sprintf("%s  program_id_keypair: \"%s\"", indent, escape(entry.ProgramIDKeypairPath)),
+			fmt.Sprintf("%s  upgrade_authority: \"%s\"", indent, escape(entry.UpgradeAuthorityPath)),
+		)
+		if mode := strings.TrimSpace(entry.Mode); mode != "" {
+			lines = append(lines, fmt.Sprintf("%s  mode: \"%s\"", indent, escape(mode)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// refreshConfigFromAnchor merges an Anchor.toml import into the existing project
+// config. Only the validator entries the import changed are rewritten;
+// comments, key order, and every other setting stay as they were.
+func refreshConfigFromAnchor(out io.Writer, file string, imported anchor.Import) error {
+	programDeploys, err := loadProgramDeployConfigs()
+	if err != nil {
+		return err
+	}
+	clonePrograms, sourcedClones, err := loadClonePrograms()
+	if err != nil {
+		return err
+	}
+	var accounts []validator.AccountFixture
+	if err := viper.UnmarshalKey("validator.accounts", &accounts); err != nil {
+		return fmt.Errorf("invalid validator.accounts in project config: %w", err)
+	}
+	cfg := validator.Config{
+		CloneRPCURL:              viper.GetString("validator.clone_rpc_url"),
+		ClonePrograms:            clonePrograms,
+		SourcedClonePrograms:     sourcedClones,
+		CloneAccounts:            viper.GetStringSlice("validator.clone_accounts"),
+		CloneUpgradeablePrograms: viper.GetStringSlice("validator.clone_upgradeable_programs"),
+		Accounts:                 accounts,
+		ProgramDeploys:           programDeploys,
+	}
+	// ApplyTo replaces entries in place, so compare against copies.
+	before := cfg
+	before.ClonePrograms = append([]string(nil), cfg.ClonePrograms...)
+	before.Accounts = append([]validator.AccountFixture(nil), cfg.Accounts...)
+	before.ProgramDeploys = append([]validator.ProgramDeployConfig(nil), cfg.ProgramDeploys...)
+	imported.ApplyTo(&cfg)
+	if err := validateAnchorRefresh(cfg); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return fmt.Errorf("read project config: %w", err)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("read project config: %w", err)
+	}
+	// Unchanged values are skipped so defaults and environment overrides
+	// read through viper are not written into the file.
+	updates := []struct {
+		key     string
+		changed bool
+		value   any
+	}{
+		{"clone_rpc_url", cfg.CloneRPCURL != before.CloneRPCURL, cfg.CloneRPCURL},
+		{"clone_programs", !slices.Equal(cfg.ClonePrograms, before.ClonePrograms), cloneProgramsConfigValue(cfg.ClonePrograms, cfg.SourcedClonePrograms)},
+		{"accounts", !slices.Equal(cfg.Accounts, before.Accounts), cfg.Accounts},
+		{"program_deploy", !slices.Equal(cfg.ProgramDeploys, before.ProgramDeploys), cfg.ProgramDeploys},
+	}
+	for _, update := range updates {
+		if !update.changed {
+			continue
+		}
+		content, err = appconfig.SetYAMLValue(content, []string{"validator", update.key}, update.value)
+		if err != nil {
+			return fmt.Errorf("update %s: %w", file, err)
+		}
+	}
+	if err := os.WriteFile(file, content, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+
+	ui.Header(out, "Config")
+	ui.Fields(out,
+		ui.Field{Label: "File", Value: file},
+		ui.Field{Label: "Anchor", Value: imported.Summary()},
+		ui.Field{Label: "Programs", Value: fmt.Sprintf("%d", len(cfg.ProgramDeploys))},
+		ui.Field{Label: "Clones", Value: fmt.Sprintf("%d", len(cfg.ClonePrograms))},
+		ui.Field{Label: "Accounts", Value: fmt.Sprintf("%d", len(cfg.Accounts))},
+	)
+	return nil
+}
+
+// validateAnchorRefresh runs full validation on the refreshed lists with the
+// remaining settings defaulted.
+func validateAnchorRefresh(cfg validator.Config) error {
+	cfg.ApplyDefaults()
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid config after Anchor.toml import: %w", err)
+	}
+	return nil
+}
+
+func renderYAMLAccountFixtures(entries []validator.AccountFixture, indent string) string {
+	if len(entries) == 0 {
+		return indent + "[]"
+	}
+	escape := func(value string) string {
+		return strings.ReplaceAll(value, `"`, `\"`)
+	}
+	lines := make([]string, 0, len(entries)*2)
+	for _, entry := range entries {
+		if dir := strings.TrimSpace(entry.Dir); dir != "" {
+			lines = append(lines, fmt.Sprintf("%s- dir: \"%s\"", indent, escape(dir)))
+			continue
+		}
+		lines = append(lines,
+			fmt.Sprintf("%s- address: \"%s\"", indent, escape(entry.Address)),
+			fmt.Sprintf("%s  path: \"%s\"", indent, escape(entry.Path)),
+		)
+	}
+	return strings.Join(lines, "\n")
+}
+
 func renderYAMLAirdropList(entries []validator.AirdropEntry, indent string) string {
 	if len(entries) == 0 {
 		return indent + "[]"
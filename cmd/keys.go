@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/CharlieAIO/sol-cloud/internal/access"
+	appconfig "github.com/CharlieAIO/sol-cloud/internal/config"
+	"github.com/CharlieAIO/sol-cloud/internal/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var keysCreateRateLimit int
+
+var keysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Manage API keys for the deployed RPC endpoint",
+	Long: "Manage the API keys in the project config's access section. Once a key exists, the RPC endpoint\n" +
+		"rejects requests without one. Changes take effect on the next `sol-cloud deploy`.",
+}
+
+var keysCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create an API key",
+	Example: `  sol-cloud keys create ci
+  sol-cloud keys create frontend --rate-limit 20`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadAccessConfig()
+		if err != nil {
+			return err
+		}
+		name := strings.TrimSpace(args[0])
+		if cfg.Find(name) >= 0 {
+			return fmt.Errorf("key %q already exists; revoke it first to rotate", name)
+		}
+		key, err := access.NewKey(name, keysCreateRateLimit)
+		if err != nil {
+			return err
+		}
+		cfg.Keys = append(cfg.Keys, key)
+		if err := saveAccessKeys(cfg.Keys); err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if structuredOutput() {
+			return writeOutputDocument(out, "access_key", toAccessKeyDocument(key, key.Key))
+		}
+		ui.Header(out, "API Key")
+		ui.Fields(out,
+			ui.Field{Label: "Name", Value: key.Name},
+			ui.Field{Label: "Key", Value: key.Key},
+			ui.Field{Label: "Rate limit", Value: rateLimitLabel(key.RateLimit)},
+			ui.Field{Label: "Header", Value: cfg.Header + ": <key>"},
+			ui.Field{Label: "Query", Value: "?" + cfg.QueryParam + "=<key>"},
+			ui.Field{Label: "Next", Value: "run `sol-cloud deploy` to apply"},
+		)
+		return nil
+	},
+}
+
+var keysListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List API keys",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadAccessConfig()
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		if structuredOutput() {
+			docs := make([]accessKeyDocument, 0, len(cfg.Keys))
+			for _, key := range cfg.Keys {
+				docs = append(docs, toAccessKeyDocument(key, ""))
+			}
+			return writeOutputDocument(out, "access_keys", docs)
+		}
+		ui.Header(out, "API Keys")
+		if len(cfg.Keys) == 0 {
+			fmt.Fprintln(out, "No API keys; the RPC endpoint is open. Create one with `sol-cloud keys create <name>`.")
+			return nil
+		}
+		fields := make([]ui.Field, 0, len(cfg.Keys))
+		for _, key := range cfg.Keys {
+			fields = append(fields, ui.Field{
+				Label: key.Name,
+				Value: fmt.Sprintf("%s  rate=%s", key.Masked(), rateLimitLabel(key.RateLimit)),
+			})
+		}
+		ui.Fields(out, fields...)
+		return nil
+	},
+}
+
+var keysRevokeCmd = &cobra.Command{
+	Use:   "revoke <name>",
+	Short: "Revoke an API key",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadAccessConfig()
+		if err != nil {
+			return err
+		}
+		name := strings.TrimSpace(args[0])
+		index := cfg.Find(name)
+		if index < 0 {
+			return fmt.Errorf("key %q not found", name)
+		}
+		revoked := cfg.Keys[index]
+		cfg.Keys = append(cfg.Keys[:index], cfg.Keys[index+1:]...)
+		if err := saveAccessKeys(cfg.Keys); err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if structuredOutput() {
+			return writeOutputDocument(out, "access_key_revoked", toAccessKeyDocument(revoked, ""))
+		}
+		next := "run `sol-cloud deploy` to apply"
+		if len(cfg.Keys) == 0 {
+			next = "no keys left; the next `sol-cloud deploy` opens the RPC endpoint"
+		}
+		ui.Header(out, "API Key Revoked")
+		ui.Fields(out,
+			ui.Field{Label: "Name", Value: revoked.Name},
+			ui.Field{Label: "Next", Value: next},
+		)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(keysCmd)
+	keysCmd.AddCommand(keysCreateCmd, keysListCmd, keysRevokeCmd)
+
+	keysCreateCmd.Flags().IntVar(&keysCreateRateLimit, "rate-limit", 0, "Requests per second allowed for this key (0: unlimited)")
+}
+
+type accessKeyDocument struct {
+	Name      string     `json:"name" yaml:"name"`
+	Key       string     `json:"key,omitempty" yaml:"key,omitempty"`
+	Masked    string     `json:"masked" yaml:"masked"`
+	RateLimit int        `json:"rate_limit" yaml:"rate_limit"`
+	CreatedAt *time.Time `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+}
+
+// toAccessKeyDocument renders key for structured output. The full key is only
+// included when passed as secret, which create does once.
+func toAccessKeyDocument(key access.Key, secret string) accessKeyDocument {
+	doc := accessKeyDocument{Name: key.Name, Key: secret, Masked: key.Masked(), RateLimit: key.RateLimit}
+	if !key.CreatedAt.IsZero() {
+		createdAt := key.CreatedAt
+		doc.CreatedAt = &createdAt
+	}
+	return doc
+}
+
+func rateLimitLabel(rateLimit int) string {
+	if rateLimit <= 0 {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%d/s", rateLimit)
+}
+
+// loadAccessConfig reads the access section of the project config with
+// defaults applied.
+func loadAccessConfig() (access.Config, error) {
+	var cfg access.Config
+	if err := viper.UnmarshalKey("access", &cfg); err != nil {
+		return cfg, fmt.Errorf("invalid access in project config: %w", err)
+	}
+	cfg.ApplyDefaults()
+	return cfg, nil
+}
+
+// saveAccessKeys writes keys back to the project config. Only the
+// access.keys entry is rewritten; comments, key order, and indentation in the
+// rest of the file stay as they were.
+func saveAccessKeys(keys []access.Key) error {
+	file := viper.ConfigFileUsed()
+	info, err := os.Stat(file)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return errors.New("no project config found; run `sol-cloud init` first")
+		}
+		return fmt.Errorf("read project config: %w", err)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("read project config: %w", err)
+	}
+	if keys == nil {
+		keys = []access.Key{}
+	}
+	updated, err := appconfig.SetYAMLValue(content, []string{"access", "keys"}, keys)
+	if err != nil {
+		return fmt.Errorf("update %s: %w", file, err)
+	}
+	if err := os.WriteFile(file, updated, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	viper.Set("access.keys", keys)
+	return nil
+}
+
+// authorizedRPCURL adds an API key to a deployment's RPC URL when the project
+// config has one, so CLI health and slot checks pass the nginx key check.
+func authorizedRPCURL(rpcURL string) string {
+	cfg, err := loadAccessConfig()
+	if err != nil {
+		return rpcURL
+	}
+	return cfg.AuthorizeURL(rpcURL)
+}
+
+// accessSummary describes the access rules for the deploy summary, or "" when
+// the endpoint is open.
+func accessSummary(cfg access.Config) string {
+	var parts []string
+	if cfg.Enabled() {
+		parts = append(parts, fmt.Sprintf("%d API key(s) via %s header or %s query param", len(cfg.Keys), cfg.Header, cfg.QueryParam))
+	}
+	if methods := cfg.Methods(); methods != nil {
+		parts = append(parts, fmt.Sprintf("%d allowed method(s)", len(methods)))
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	appconfig "github.com/CharlieAIO/sol-cloud/internal/config"
+	"github.com/CharlieAIO/sol-cloud/internal/providers"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logsName   string
+	logsFollow bool
+	logsSince  time.Duration
+	logsGrep   string
+)
+
+var logsCmd = &cobra.Command{
+	Use:   "logs [name]",
+	Short: "Show validator container logs",
+	Long: `Print validator, entrypoint, and nginx logs from a deployed validator.
+
+Logs are read from the Fly logs API or Railway deployment logs. Use --follow to keep
+streaming new lines until Ctrl+C.`,
+	Example: `  sol-cloud logs
+  sol-cloud logs --follow
+  sol-cloud logs my-validator --since 10m --grep airdrop`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.TrimSpace(logsName)
+		if name == "" && len(args) > 0 {
+			name = strings.TrimSpace(args[0])
+		}
+		if logsSince < 0 {
+			return fmt.Errorf("--since must be >= 0")
+		}
+
+		projectDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("get working directory: %w", err)
+		}
+
+		state, err := appconfig.LoadState(projectDir)
+		if err != nil {
+			return fmt.Errorf("load local deployment state: %w", err)
+		}
+
+		record, err := resolveStatusRecord(state, name)
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(record.Provider) == "" {
+			record.Provider = "fly"
+		}
+
+		provider, err := providers.NewProvider(record.Provider)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		out := cmd.OutOrStdout()
+		return provider.Logs(ctx, record.Name, providers.LogOptions{
+			Follow: logsFollow,
+			Since:  logsSince,
+			Grep:   logsGrep,
+			OnEntry: func(entry providers.LogEntry) {
+				fmt.Fprintln(out, formatLogEntry(entry))
+			},
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(logsCmd)
+
+	logsCmd.Flags().StringVar(&logsName, "name", "", "Deployment name (defaults to last deployment from local state)")
+	logsCmd.Flags().BoolVarP(&logsFollow, "follow", "f", false, "Keep streaming new log lines until interrupted")
+	logsCmd.Flags().DurationVar(&logsSince, "since", 0, "Only show lines newer than this duration (e.g. 10m, 1h)")
+	logsCmd.Flags().StringVar(&logsGrep, "grep", "", "Only show lines containing this text (case-insensitive)")
+}
+
+func formatLogEntry(entry providers.LogEntry) string {
+	var b strings.Builder
+	if !entry.Timestamp.IsZero() {
+		b.WriteString(entry.Timestamp.Local().Format("15:04:05"))
+		b.WriteByte(' ')
+	}
+	if entry.Instance != "" {
+		b.WriteString("[" + entry.Instance + "] ")
+	}
+	b.WriteString(entry.Message)
+	return b.String()
+}
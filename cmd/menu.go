@@ -18,7 +18,9 @@ var mainMenuOptions = []utils.Option{
 	{Key: "init", Label: "Create or update hidden project config"},
 	{Key: "auth-fly", Label: "Connect Fly.io"},
 	{Key: "auth-railway", Label: "Connect Railway"},
+	{Key: "logs", Label: "Show validator logs"},
 	{Key: "watch", Label: "Watch validator"},
+	{Key: "reset", Label: "Reset validator ledger"},
 	{Key: "clone-program", Label: "Clone Solana program"},
 	{Key: "destroy", Label: "Destroy validator"},
 	{Key: "help", Label: "Show command help"},
@@ -83,8 +85,12 @@ func runMenuChoice(cmd *cobra.Command, reader *bufio.Reader, choice string) erro
 		return runExistingCommand(cmd, authFlyCmd, nil)
 	case "auth-railway", "railway":
 		return runExistingCommand(cmd, authRailwayCmd, nil)
+	case "logs":
+		return runExistingCommand(cmd, logsCmd, nil)
 	case "watch":
 		return runExistingCommand(cmd, watchCmd, nil)
+	case "reset":
+		return runExistingCommand(cmd, resetCmd, nil)
 	case "destroy":
 		return runExistingCommand(cmd, destroyCmd, nil)
 	case "clone-program", "clone":
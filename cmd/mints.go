@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/CharlieAIO/sol-cloud/internal/solana"
+	"github.com/CharlieAIO/sol-cloud/internal/validator"
+)
+
+// loadMintAuthorityOverride resolves the mint and optional freeze authority,
+// each a pubkey or a keypair file path relative to projectDir.
+func loadMintAuthorityOverride(projectDir, mintAuthority, freezeAuthority string) (solana.MintAuthorityOverride, error) {
+	var override solana.MintAuthorityOverride
+	key, err := resolvePublicKeyArg(projectDir, mintAuthority)
+	if err != nil {
+		return override, fmt.Errorf("mint authority: %w", err)
+	}
+	override.MintAuthority = key
+	if strings.TrimSpace(freezeAuthority) != "" {
+		key, err := resolvePublicKeyArg(projectDir, freezeAuthority)
+		if err != nil {
+			return override, fmt.Errorf("freeze authority: %w", err)
+		}
+		override.FreezeAuthority = &key
+	}
+	return override, nil
+}
+
+func resolvePublicKeyArg(projectDir, value string) (solana.PublicKey, error) {
+	value = strings.TrimSpace(value)
+	if validator.IsAddress(value) {
+		return solana.ParsePublicKey(value)
+	}
+	path := value
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(projectDir, path)
+	}
+	return solana.ReadKeypairPublicKey(path)
+}
+
+// rewriteClonedMints applies validator.clone_mint_authority at deploy time. It
+// fetches clone_programs and clone_accounts from the clone RPC, rewrites the
+// authorities of every mint among them into .sol-cloud/mints/<address>.json,
+// and moves those mints from the clone lists to account fixtures. Other clones
+// are left for the validator to fetch at startup. It returns the rewritten
+// mint addresses.
+func rewriteClonedMints(ctx context.Context, projectDir string, cfg *validator.Config) ([]string, error) {
+	if strings.TrimSpace(cfg.CloneMintAuthority) == "" {
+		return nil, nil
+	}
+	override, err := loadMintAuthorityOverride(projectDir, cfg.CloneMintAuthority, cfg.CloneFreezeAuthority)
+	if err != nil {
+		return nil, fmt.Errorf("clone_mint_authority: %w", err)
+	}
+
+	var addresses []string
+	for _, address := range mergeAddresses(append([]string(nil), cfg.ClonePrograms...), cfg.CloneAccounts) {
+		addresses = append(addresses, strings.TrimSpace(address))
+	}
+	if len(addresses) == 0 {
+		return nil, nil
+	}
+	snapshots, err := solana.FetchAccountSnapshots(ctx, cfg.CloneRPCURL, addresses)
+	if err != nil {
+		return nil, fmt.Errorf("fetch clones from %s for clone_mint_authority: %w", cfg.CloneRPCURL, err)
+	}
+
+	mintsDir := filepath.Join(projectDir, ".sol-cloud", "mints")
+	rewritten := map[string]struct{}{}
+	var mints []string
+	for _, address := range addresses {
+		snapshot, ok := snapshots[address]
+		if !ok {
+			continue
+		}
+		data, isMint, err := solana.RewriteMintAuthorities(snapshot, override)
+		if err != nil {
+			return nil, fmt.Errorf("rewrite mint %s: %w", address, err)
+		}
+		if !isMint {
+			continue
+		}
+		if err := os.MkdirAll(mintsDir, 0o755); err != nil {
+			return nil, fmt.Errorf("create mints directory: %w", err)
+		}
+		path := filepath.Join(mintsDir, address+".json")
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return nil, fmt.Errorf("write mint snapshot %s: %w", address, err)
+		}
+		cfg.Accounts = append(cfg.Accounts, validator.AccountFixture{Address: address, Path: path})
+		rewritten[address] = struct{}{}
+		mints = append(mints, address)
+	}
+
+	cfg.ClonePrograms = withoutAddresses(cfg.ClonePrograms, rewritten)
+	cfg.CloneAccounts = withoutAddresses(cfg.CloneAccounts, rewritten)
+	return mints, nil
+}
+
+// withoutAddresses returns list without the addresses in drop.
+func withoutAddresses(list []string, drop map[string]struct{}) []string {
+	out := make([]string, 0, len(list))
+	for _, value := range list {
+		if _, ok := drop[strings.TrimSpace(value)]; ok {
+			continue
+		}
+		out = append(out, value)
+	}
+	return out
+}
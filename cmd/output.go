@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	appconfig "github.com/CharlieAIO/sol-cloud/internal/config"
+	"github.com/CharlieAIO/sol-cloud/internal/providers"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// outputSchemaVersion is bumped whenever a field in a structured output
+// document is renamed or removed. Adding fields does not change it.
+const outputSchemaVersion = 1
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+	outputYAML = "yaml"
+)
+
+var outputFormat string
+
+// outputDocument wraps every structured result so scripts can check the schema
+// version and kind before reading data.
+type outputDocument struct {
+	SchemaVersion int    `json:"schema_version" yaml:"schema_version"`
+	Kind          string `json:"kind" yaml:"kind"`
+	Data          any    `json:"data" yaml:"data"`
+}
+
+type deploymentDocument struct {
+	Name         string `json:"name" yaml:"name"`
+	Provider     string `json:"provider" yaml:"provider"`
+	Region       string `json:"region,omitempty" yaml:"region,omitempty"`
+	RPCURL       string `json:"rpc_url" yaml:"rpc_url"`
+	WebSocketURL string `json:"websocket_url" yaml:"websocket_url"`
+	DashboardURL string `json:"dashboard_url,omitempty" yaml:"dashboard_url,omitempty"`
+	ArtifactsDir string `json:"artifacts_dir" yaml:"artifacts_dir"`
+	StateFile    string `json:"state_file,omitempty" yaml:"state_file,omitempty"`
+	DryRun       bool   `json:"dry_run" yaml:"dry_run"`
+}
+
+type statusDocument struct {
+	Name            string             `json:"name" yaml:"name"`
+	Provider        string             `json:"provider" yaml:"provider"`
+	State           string             `json:"state" yaml:"state"`
+	Health          string             `json:"health" yaml:"health"`
+	Slot            *uint64            `json:"slot" yaml:"slot"`
+	TPS             *float64           `json:"tps" yaml:"tps"`
+	RPCURL          string             `json:"rpc_url" yaml:"rpc_url"`
+	WebSocketURL    string             `json:"websocket_url" yaml:"websocket_url"`
+	DashboardURL    string             `json:"dashboard_url,omitempty" yaml:"dashboard_url,omitempty"`
+	LastOperation   *operationDocument `json:"last_operation,omitempty" yaml:"last_operation,omitempty"`
+	Programs        []programDocument  `json:"programs,omitempty" yaml:"programs,omitempty"`
+	Startup         *startupReport     `json:"startup,omitempty" yaml:"startup,omitempty"`
+	ProviderWarning string             `json:"provider_warning,omitempty" yaml:"provider_warning,omitempty"`
+}
+
+type operationDocument struct {
+	ID         string     `json:"id" yaml:"id"`
+	Type       string     `json:"type" yaml:"type"`
+	Status     string     `json:"status" yaml:"status"`
+	Message    string     `json:"message,omitempty" yaml:"message,omitempty"`
+	StartedAt  time.Time  `json:"started_at" yaml:"started_at"`
+	UpdatedAt  time.Time  `json:"updated_at" yaml:"updated_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty" yaml:"finished_at,omitempty"`
+}
+
+type programDocument struct {
+	ProgramID  string    `json:"program_id" yaml:"program_id"`
+	SHA256     string    `json:"sha256" yaml:"sha256"`
+	Size       int64     `json:"size" yaml:"size"`
+	SOPath     string    `json:"so_path" yaml:"so_path"`
+	UpgradedAt time.Time `json:"upgraded_at" yaml:"upgraded_at"`
+}
+
+// startupReport mirrors /sol-cloud/startup.json, which the container
+// entrypoint rewrites as the validator starts and its startup hooks run.
+type startupReport struct {
+	Status             string                 `json:"status" yaml:"status"`
+	Message            string                 `json:"message,omitempty" yaml:"message,omitempty"`
+	SolanaVersion      string                 `json:"solana_version" yaml:"solana_version"`
+	StartedAt          time.Time              `json:"started_at" yaml:"started_at"`
+	UpdatedAt          time.Time              `json:"updated_at" yaml:"updated_at"`
+	ValidatorStartedAt *time.Time             `json:"validator_started_at,omitempty" yaml:"validator_started_at,omitempty"`
+	Ledger             startupLedger          `json:"ledger" yaml:"ledger"`
+	Clones             []startupCloneResult   `json:"clones" yaml:"clones"`
+	Programs           []startupProgramResult `json:"programs" yaml:"programs"`
+	Airdrops           []startupAirdropResult `json:"airdrops" yaml:"airdrops"`
+}
+
+type startupLedger struct {
+	Preserved bool                 `json:"preserved" yaml:"preserved"`
+	Resets    []startupLedgerReset `json:"resets" yaml:"resets"`
+}
+
+type startupLedgerReset struct {
+	Time   time.Time `json:"time" yaml:"time"`
+	Reason string    `json:"reason" yaml:"reason"`
+}
+
+type startupCloneResult struct {
+	Address string `json:"address" yaml:"address"`
+	Kind    string `json:"kind" yaml:"kind"`
+	Result  string `json:"result" yaml:"result"`
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+type startupProgramResult struct {
+	ProgramID string `json:"program_id" yaml:"program_id"`
+	SOPath    string `json:"so_path" yaml:"so_path"`
+	Mode      string `json:"mode" yaml:"mode"`
+	Result    string `json:"result" yaml:"result"`
+	Message   string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+type startupAirdropResult struct {
+	Address string `json:"address" yaml:"address"`
+	Amount  uint64 `json:"amount" yaml:"amount"`
+	Result  string `json:"result" yaml:"result"`
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+type destroyDocument struct {
+	Name      string `json:"name" yaml:"name"`
+	Provider  string `json:"provider" yaml:"provider"`
+	Destroyed bool   `json:"destroyed" yaml:"destroyed"`
+	StateFile string `json:"state_file,omitempty" yaml:"state_file,omitempty"`
+}
+
+type resetDocument struct {
+	Name     string `json:"name" yaml:"name"`
+	Provider string `json:"provider" yaml:"provider"`
+	RPCURL   string `json:"rpc_url" yaml:"rpc_url"`
+}
+
+type cloneDocument struct {
+	ProgramID         string                  `json:"program_id" yaml:"program_id"`
+	SourceRPC         string                  `json:"source_rpc" yaml:"source_rpc"`
+	BinaryPath        string                  `json:"binary_path" yaml:"binary_path"`
+	Accounts          []accountSnapshotRecord `json:"accounts" yaml:"accounts"`
+	AccountFlagsPath  string                  `json:"account_flags_path,omitempty" yaml:"account_flags_path,omitempty"`
+	TargetRPC         string                  `json:"target_rpc,omitempty" yaml:"target_rpc,omitempty"`
+	DeployedProgramID string                  `json:"deployed_program_id,omitempty" yaml:"deployed_program_id,omitempty"`
+}
+
+func validateOutputFormat() error {
+	format := strings.ToLower(strings.TrimSpace(outputFormat))
+	if format == "" {
+		format = outputText
+	}
+	switch format {
+	case outputText, outputJSON, outputYAML:
+		outputFormat = format
+		return nil
+	default:
+		return fmt.Errorf("unsupported --output %q: use text, json, or yaml", outputFormat)
+	}
+}
+
+// structuredOutput reports whether stdout is reserved for a json/yaml document.
+func structuredOutput() bool {
+	return outputFormat == outputJSON || outputFormat == outputYAML
+}
+
+// progressOutput returns where progress lines and prompts go. In json/yaml mode
+// they move to stderr so stdout stays parseable.
+func progressOutput(cmd *cobra.Command) io.Writer {
+	if structuredOutput() {
+		return cmd.ErrOrStderr()
+	}
+	return cmd.OutOrStdout()
+}
+
+// writeOutputDocument writes one versioned document in the selected format.
+func writeOutputDocument(w io.Writer, kind string, data any) error {
+	doc := outputDocument{SchemaVersion: outputSchemaVersion, Kind: kind, Data: data}
+	switch outputFormat {
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(doc)
+	case outputYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		return fmt.Errorf("output format %q is not structured", outputFormat)
+	}
+}
+
+// outputStream writes a sequence of versioned documents: one JSON object per
+// line in json mode, or "---"-separated documents in yaml mode.
+type outputStream struct {
+	mu   sync.Mutex
+	kind string
+	json *json.Encoder
+	yaml *yaml.Encoder
+}
+
+func newOutputStream(w io.Writer, kind string) *outputStream {
+	stream := &outputStream{kind: kind}
+	if outputFormat == outputYAML {
+		stream.yaml = yaml.NewEncoder(w)
+		stream.yaml.SetIndent(2)
+	} else {
+		stream.json = json.NewEncoder(w)
+	}
+	return stream
+}
+
+func (s *outputStream) Write(data any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	doc := outputDocument{SchemaVersion: outputSchemaVersion, Kind: s.kind, Data: data}
+	if s.yaml != nil {
+		return s.yaml.Encode(doc)
+	}
+	return s.json.Encode(doc)
+}
+
+func toDeploymentDocument(deployment *providers.Deployment, region, stateFile string, dryRun bool) deploymentDocument {
+	return deploymentDocument{
+		Name:         deployment.Name,
+		Provider:     deployment.Provider,
+		Region:       region,
+		RPCURL:       deployment.RPCURL,
+		WebSocketURL: deployment.WebSocketURL,
+		DashboardURL: deployment.DashboardURL,
+		ArtifactsDir: deployment.ArtifactsDir,
+		StateFile:    stateFile,
+		DryRun:       dryRun,
+	}
+}
+
+func toOperationDocument(operation appconfig.OperationRecord) *operationDocument {
+	return &operationDocument{
+		ID:         operation.ID,
+		Type:       operation.Type,
+		Status:     operation.Status,
+		Message:    operation.Message,
+		StartedAt:  operation.StartedAt,
+		UpdatedAt:  operation.UpdatedAt,
+		FinishedAt: operation.FinishedAt,
+	}
+}
+
+func toProgramDocument(program appconfig.ProgramRecord) programDocument {
+	return programDocument{
+		ProgramID:  program.ProgramID,
+		SHA256:     program.SHA256,
+		Size:       program.Size,
+		SOPath:     program.SOPath,
+		UpgradedAt: program.UpgradedAt,
+	}
+}
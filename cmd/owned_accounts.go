@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/CharlieAIO/sol-cloud/internal/solana"
+	"github.com/CharlieAIO/sol-cloud/internal/validator"
+)
+
+// fetchOwnedAccounts returns the accounts owned by programID that match
+// filter. The matches are counted with an empty data slice first, and more
+// than maxAccounts is an error rather than a truncated list, so a missing
+// filter cannot quietly pull half a protocol.
+func fetchOwnedAccounts(ctx context.Context, client *solana.Client, programID string, filter solana.ProgramAccountsFilter, maxAccounts int) ([]solana.KeyedAccount, error) {
+	if maxAccounts > 0 {
+		addresses, err := client.GetProgramAccountKeys(ctx, programID, filter)
+		if err != nil {
+			return nil, fmt.Errorf("count accounts owned by %s: %w", programID, err)
+		}
+		if len(addresses) > maxAccounts {
+			return nil, fmt.Errorf("program %s owns %d matching accounts, more than the limit of %d; narrow the filters or raise the max accounts", programID, len(addresses), maxAccounts)
+		}
+	}
+	accounts, err := client.GetProgramAccounts(ctx, programID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list accounts owned by %s: %w", programID, err)
+	}
+	// Accounts can be created between the two calls.
+	if maxAccounts > 0 && len(accounts) > maxAccounts {
+		return nil, fmt.Errorf("program %s owns %d matching accounts, more than the limit of %d; narrow the filters or raise the max accounts", programID, len(accounts), maxAccounts)
+	}
+	return accounts, nil
+}
+
+// snapshotOwnedAccounts applies validator.clone_owned_accounts at deploy time.
+// Each entry's matches are written to .sol-cloud/owned-accounts/NN-<program>
+// and added as a dir account fixture; addresses are also dropped from the
+// clone lists so they are not loaded twice. It returns the number of accounts
+// written.
+func snapshotOwnedAccounts(ctx context.Context, projectDir string, cfg *validator.Config) (int, error) {
+	if len(cfg.CloneOwnedAccounts) == 0 {
+		return 0, nil
+	}
+	client := solana.NewClient(cfg.CloneRPCURL)
+	baseDir := filepath.Join(projectDir, ".sol-cloud", "owned-accounts")
+	// Rebuild the directory so accounts that no longer match do not linger.
+	if err := os.RemoveAll(baseDir); err != nil {
+		return 0, fmt.Errorf("clear owned accounts directory: %w", err)
+	}
+
+	written := map[string]struct{}{}
+	for i, entry := range cfg.CloneOwnedAccounts {
+		filter, err := entry.Filter()
+		if err != nil {
+			return 0, fmt.Errorf("clone_owned_accounts[%d]: %w", i, err)
+		}
+		accounts, err := fetchOwnedAccounts(ctx, client, entry.Program, filter, entry.MaxAccounts)
+		if err != nil {
+			return 0, fmt.Errorf("clone_owned_accounts[%d]: %w", i, err)
+		}
+
+		dir := filepath.Join(baseDir, fmt.Sprintf("%02d-%s", i+1, entry.Program))
+		count := 0
+		for _, keyed := range accounts {
+			if _, ok := written[keyed.Address]; ok {
+				continue
+			}
+			if count == 0 {
+				if err := os.MkdirAll(dir, 0o755); err != nil {
+					return 0, fmt.Errorf("create owned accounts directory: %w", err)
+				}
+			}
+			data, err := solana.AccountSnapshotJSON(keyed.Address, keyed.Account)
+			if err != nil {
+				return 0, fmt.Errorf("encode account %s: %w", keyed.Address, err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, keyed.Address+".json"), data, 0o644); err != nil {
+				return 0, fmt.Errorf("write account snapshot %s: %w", keyed.Address, err)
+			}
+			written[keyed.Address] = struct{}{}
+			count++
+		}
+		if count > 0 {
+			cfg.Accounts = append(cfg.Accounts, validator.AccountFixture{Dir: dir})
+		}
+	}
+
+	cfg.ClonePrograms = withoutAddresses(cfg.ClonePrograms, written)
+	cfg.CloneAccounts = withoutAddresses(cfg.CloneAccounts, written)
+	return len(written), nil
+}
@@ -0,0 +1,299 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	appconfig "github.com/CharlieAIO/sol-cloud/internal/config"
+	"github.com/CharlieAIO/sol-cloud/internal/solana"
+	"github.com/CharlieAIO/sol-cloud/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	programUpgradeName      string
+	programUpgradeSO        string
+	programUpgradeProgramID string
+	programUpgradeAuthority string
+	programUpgradeTimeout   time.Duration
+)
+
+var programCmd = &cobra.Command{
+	Use:   "program",
+	Short: "Manage programs on a running validator",
+}
+
+var programUpgradeCmd = &cobra.Command{
+	Use:   "upgrade [name]",
+	Short: "Upgrade a program on a running validator without redeploying",
+	Long: `Write a program binary to a buffer over the deployment's RPC endpoint and
+upgrade an existing upgradeable program from it. The container is not rebuilt or
+restarted. Requires the Solana CLI; the authority keypair pays for the buffer.
+
+The binary's SHA-256 is recorded in local state so ` + "`sol-cloud status`" + ` can show
+which build is live. The record is cleared when the ledger is wiped.`,
+	Example: `  sol-cloud program upgrade --so ./target/deploy/my_program.so --program-id ./target/deploy/my_program-keypair.json --authority ~/.config/solana/id.json
+  sol-cloud program upgrade my-validator --so ./target/deploy/my_program.so --program-id <program-id>`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.TrimSpace(programUpgradeName)
+		if name == "" && len(args) > 0 {
+			name = strings.TrimSpace(args[0])
+		}
+		if strings.TrimSpace(programUpgradeSO) == "" {
+			return errors.New("--so is required")
+		}
+		if strings.TrimSpace(programUpgradeProgramID) == "" {
+			return errors.New("--program-id is required")
+		}
+		if programUpgradeTimeout <= 0 {
+			return fmt.Errorf("--timeout must be > 0")
+		}
+		if _, err := exec.LookPath("solana"); err != nil {
+			return fmt.Errorf("solana CLI not found in PATH (required by program upgrade): %w", err)
+		}
+
+		projectDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("get working directory: %w", err)
+		}
+		soPath, err := filepath.Abs(strings.TrimSpace(programUpgradeSO))
+		if err != nil {
+			return fmt.Errorf("resolve --so: %w", err)
+		}
+		binary, err := os.ReadFile(soPath)
+		if err != nil {
+			return fmt.Errorf("read program binary: %w", err)
+		}
+		sum := sha256.Sum256(binary)
+		programKey, err := resolvePublicKeyArg(projectDir, programUpgradeProgramID)
+		if err != nil {
+			return fmt.Errorf("--program-id: %w", err)
+		}
+		programID := programKey.String()
+
+		state, err := appconfig.LoadState(projectDir)
+		if err != nil {
+			return fmt.Errorf("load local deployment state: %w", err)
+		}
+		record, err := resolveStatusRecord(state, name)
+		if err != nil {
+			return err
+		}
+		providerName := strings.TrimSpace(record.Provider)
+		if providerName == "" {
+			providerName = "fly"
+		}
+		rpcURL := authorizedRPCURL(record.RPCURL)
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), programUpgradeTimeout)
+		defer cancel()
+
+		progress := ui.NewProgress(progressOutput(cmd), 4)
+		progress.Start("Checking program " + programID)
+		client := solana.NewClient(rpcURL)
+		account, err := client.GetAccountInfo(ctx, programID)
+		if err != nil {
+			progress.Fail("Program upgrade failed")
+			return err
+		}
+		if account == nil || account.ProgramDataAddress() == "" {
+			progress.Fail("Program upgrade failed")
+			return fmt.Errorf("%s is not an upgradeable program on %s; deploy it first", programID, record.Name)
+		}
+		programData, err := client.GetAccountInfo(ctx, account.ProgramDataAddress())
+		if err != nil {
+			progress.Fail("Program upgrade failed")
+			return err
+		}
+		capacity := programData.ProgramDataCapacity()
+		if capacity < 0 {
+			progress.Fail("Program upgrade failed")
+			return fmt.Errorf("programdata account of %s has been closed", programID)
+		}
+
+		operation, err := state.StartOperation("program-upgrade", record.Name, providerName, "upgrading "+programID)
+		if err != nil {
+			progress.Fail("Program upgrade failed")
+			return fmt.Errorf("start program upgrade operation state: %w", err)
+		}
+		if err := appconfig.SaveState(projectDir, state); err != nil {
+			progress.Fail("Program upgrade failed")
+			return fmt.Errorf("save program upgrade operation state: %w", err)
+		}
+		fail := func(err error) error {
+			progress.Fail("Program upgrade failed")
+			_ = state.FinishOperation(operation.ID, "failed", err.Error())
+			_ = appconfig.SaveState(projectDir, state)
+			return err
+		}
+
+		authority := strings.TrimSpace(programUpgradeAuthority)
+		progress.Step(fmt.Sprintf("Writing %d bytes to a buffer", len(binary)))
+		buffer, err := writeProgramBuffer(ctx, rpcURL, soPath, authority)
+		if err != nil {
+			return fail(err)
+		}
+
+		if grow := len(binary) - capacity; grow > 0 {
+			// The upgrade instruction fails unless programdata fits the new binary.
+			progress.Detail(fmt.Sprintf("Extending programdata by %d bytes", grow))
+			if err := extendProgram(ctx, rpcURL, programID, grow, authority); err != nil {
+				return fail(fmt.Errorf("%w\nbuffer %s still holds the binary; reclaim its rent with `solana program close %s`", err, buffer, buffer))
+			}
+		}
+
+		progress.Step("Upgrading " + programID)
+		signature, err := upgradeProgram(ctx, rpcURL, buffer, programID, authority)
+		if err != nil {
+			return fail(fmt.Errorf("%w\nbuffer %s still holds the binary; reclaim its rent with `solana program close %s`", err, buffer, buffer))
+		}
+
+		progress.Step("Saving program state")
+		program := appconfig.ProgramRecord{
+			ProgramID:  programID,
+			SHA256:     hex.EncodeToString(sum[:]),
+			Size:       int64(len(binary)),
+			SOPath:     soPath,
+			UpgradedAt: time.Now().UTC(),
+		}
+		if err := state.RecordProgram(record.Name, program); err != nil && !errors.Is(err, appconfig.ErrDeploymentNotFound) {
+			return fail(err)
+		}
+		if err := state.FinishOperation(operation.ID, "succeeded", "upgraded "+programID); err != nil {
+			progress.Fail("Program upgrade failed")
+			return fmt.Errorf("finish program upgrade operation state: %w", err)
+		}
+		if err := appconfig.SaveState(projectDir, state); err != nil {
+			progress.Fail("Program upgrade failed")
+			return fmt.Errorf("save local deployment state: %w", err)
+		}
+		progress.Success("Program upgraded")
+
+		out := cmd.OutOrStdout()
+		if structuredOutput() {
+			return writeOutputDocument(out, "program_upgrade", programUpgradeDocument{
+				Name:      record.Name,
+				Buffer:    buffer,
+				Signature: signature,
+				Program:   toProgramDocument(program),
+			})
+		}
+		ui.Header(out, "Program Upgrade")
+		ui.Fields(out,
+			ui.Field{Label: "Validator", Value: record.Name},
+			ui.Field{Label: "Program", Value: programID},
+			ui.Field{Label: "Binary", Value: soPath},
+			ui.Field{Label: "SHA-256", Value: program.SHA256},
+			ui.Field{Label: "Buffer", Value: buffer},
+			ui.Field{Label: "Signature", Value: signature},
+		)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(programCmd)
+	programCmd.AddCommand(programUpgradeCmd)
+
+	programUpgradeCmd.Flags().StringVar(&programUpgradeName, "name", "", "Deployment name (defaults to last deployment from local state)")
+	programUpgradeCmd.Flags().StringVar(&programUpgradeSO, "so", "", "Path to the new program binary (.so)")
+	programUpgradeCmd.Flags().StringVar(&programUpgradeProgramID, "program-id", "", "Program ID or program keypair path")
+	programUpgradeCmd.Flags().StringVar(&programUpgradeAuthority, "authority", "", "Upgrade authority keypair path; also pays for the buffer (default: Solana CLI keypair)")
+	programUpgradeCmd.Flags().DurationVar(&programUpgradeTimeout, "timeout", 10*time.Minute, "Timeout for writing the buffer and upgrading")
+}
+
+type programUpgradeDocument struct {
+	Name      string          `json:"name" yaml:"name"`
+	Buffer    string          `json:"buffer" yaml:"buffer"`
+	Signature string          `json:"signature,omitempty" yaml:"signature,omitempty"`
+	Program   programDocument `json:"program" yaml:"program"`
+}
+
+// writeProgramBuffer runs `solana program write-buffer` and returns the buffer
+// address. The authority keypair pays for and owns the buffer.
+func writeProgramBuffer(ctx context.Context, rpcURL, soPath, authority string) (string, error) {
+	args := []string{"program", "write-buffer", soPath, "-u", rpcURL, "--output", "json"}
+	if authority != "" {
+		args = append(args, "--keypair", authority)
+	}
+	output, err := runSolana(ctx, args...)
+	if err != nil {
+		return "", fmt.Errorf("solana program write-buffer failed: %w\n%s", err, strings.TrimSpace(output))
+	}
+	var result struct {
+		Buffer string `json:"buffer"`
+	}
+	if err := json.Unmarshal([]byte(lastJSONObject(output)), &result); err != nil || result.Buffer == "" {
+		return "", fmt.Errorf("solana program write-buffer: buffer address not found in output:\n%s", strings.TrimSpace(output))
+	}
+	return result.Buffer, nil
+}
+
+// extendProgram runs `solana program extend` to grow the programdata account
+// of programID by additionalBytes. The authority keypair pays the extra rent.
+func extendProgram(ctx context.Context, rpcURL, programID string, additionalBytes int, authority string) error {
+	args := []string{"program", "extend", programID, strconv.Itoa(additionalBytes), "-u", rpcURL}
+	if authority != "" {
+		args = append(args, "--keypair", authority)
+	}
+	output, err := runSolana(ctx, args...)
+	if err != nil {
+		return fmt.Errorf("solana program extend failed: %w\n%s", err, strings.TrimSpace(output))
+	}
+	return nil
+}
+
+// upgradeProgram runs `solana program upgrade` and returns the transaction
+// signature when the CLI reports one.
+func upgradeProgram(ctx context.Context, rpcURL, buffer, programID, authority string) (string, error) {
+	args := []string{"program", "upgrade", buffer, programID, "-u", rpcURL, "--output", "json"}
+	if authority != "" {
+		args = append(args, "--keypair", authority, "--upgrade-authority", authority)
+	}
+	output, err := runSolana(ctx, args...)
+	if err != nil {
+		return "", fmt.Errorf("solana program upgrade failed: %w\n%s", err, strings.TrimSpace(output))
+	}
+	var result struct {
+		Signature string `json:"signature"`
+	}
+	_ = json.Unmarshal([]byte(lastJSONObject(output)), &result)
+	return result.Signature, nil
+}
+
+// lastJSONObject returns the last {...} block of CLI output, skipping any
+// progress lines printed before it.
+func lastJSONObject(output string) string {
+	end := strings.LastIndex(output, "}")
+	if end < 0 {
+		return ""
+	}
+	start := strings.LastIndex(output[:end], "{")
+	if start < 0 {
+		return ""
+	}
+	return output[start : end+1]
+}
+
+// programFields describes the programs upgraded onto a deployment.
+func programFields(programs []appconfig.ProgramRecord) []ui.Field {
+	fields := make([]ui.Field, 0, len(programs))
+	for _, program := range programs {
+		fields = append(fields, ui.Field{
+			Label: "Program " + program.ProgramID,
+			Value: fmt.Sprintf("sha256 %s (%s, upgraded %s)", program.SHA256[:min(12, len(program.SHA256))], filepath.Base(program.SOPath), program.UpgradedAt.Local().Format(time.RFC3339)),
+		})
+	}
+	return fields
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	appconfig "github.com/CharlieAIO/sol-cloud/internal/config"
+	"github.com/CharlieAIO/sol-cloud/internal/providers"
+	"github.com/CharlieAIO/sol-cloud/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+const resetPollInterval = 3 * time.Second
+
+var (
+	resetName    string
+	resetYes     bool
+	resetTimeout time.Duration
+)
+
+var resetCmd = &cobra.Command{
+	Use:   "reset [name]",
+	Short: "Wipe the validator ledger and restart from genesis",
+	Long: `Wipe the ledger of a running validator once and restart it from genesis.
+
+The image is not rebuilt or redeployed. Fly machines and Docker containers are
+signalled in place; Railway services get a one-shot reset token and are redeployed
+with the existing image. Startup hooks (program deploy, airdrops) run again.`,
+	Example: `  sol-cloud reset
+  sol-cloud reset my-validator --yes`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.TrimSpace(resetName)
+		if name == "" && len(args) > 0 {
+			name = strings.TrimSpace(args[0])
+		}
+		if resetTimeout <= 0 {
+			return fmt.Errorf("--timeout must be > 0")
+		}
+
+		projectDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("get working directory: %w", err)
+		}
+
+		state, err := appconfig.LoadState(projectDir)
+		if err != nil {
+			return fmt.Errorf("load local deployment state: %w", err)
+		}
+
+		record, err := resolveStatusRecord(state, name)
+		if err != nil {
+			return err
+		}
+		providerName := strings.TrimSpace(record.Provider)
+		if providerName == "" {
+			providerName = "fly"
+		}
+
+		if !resetYes {
+			confirmed, confirmErr := confirmReset(cmd, record.Name)
+			if confirmErr != nil {
+				return confirmErr
+			}
+			if !confirmed {
+				fmt.Fprintln(progressOutput(cmd), "reset cancelled")
+				return nil
+			}
+		}
+
+		provider, err := providers.NewProvider(providerName)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		progress := ui.NewProgress(progressOutput(cmd), 4)
+		progress.Start("Saving reset operation state")
+		operation, err := state.StartOperation("reset", record.Name, providerName, "ledger reset started")
+		if err != nil {
+			progress.Fail("Reset failed")
+			return fmt.Errorf("start reset operation state: %w", err)
+		}
+		if err := appconfig.SaveState(projectDir, state); err != nil {
+			progress.Fail("Reset failed")
+			return fmt.Errorf("save reset operation state: %w", err)
+		}
+
+		fail := func(err error) error {
+			progress.Fail("Reset failed")
+			_ = state.FinishOperation(operation.ID, "failed", err.Error())
+			_ = appconfig.SaveState(projectDir, state)
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), resetTimeout)
+		defer cancel()
+
+		var genesisBefore string
+		_ = rpcCall(ctx, authorizedRPCURL(record.RPCURL), "getGenesisHash", nil, &genesisBefore)
+
+		progress.Step("Requesting ledger reset")
+		if err := provider.ResetLedger(ctx, record.Name); err != nil {
+			return fail(err)
+		}
+
+		progress.Step("Waiting for validator to restart from genesis")
+		if err := waitForFreshLedger(ctx, authorizedRPCURL(record.RPCURL), genesisBefore); err != nil {
+			return fail(err)
+		}
+
+		progress.Step("Waiting for RPC health")
+		if err := providers.WaitForRPCHealthy(ctx, authorizedRPCURL(record.RPCURL), resetTimeout, resetPollInterval); err != nil {
+			return fail(err)
+		}
+
+		state.ClearPrograms(record.Name)
+		if err := state.FinishOperation(operation.ID, "succeeded", "ledger reset completed"); err != nil {
+			progress.Fail("Reset failed")
+			return fmt.Errorf("finish reset operation state: %w", err)
+		}
+		if err := appconfig.SaveState(projectDir, state); err != nil {
+			progress.Fail("Reset failed")
+			return fmt.Errorf("save local deployment state: %w", err)
+		}
+
+		progress.Success("Ledger reset")
+		if structuredOutput() {
+			return writeOutputDocument(out, "reset", resetDocument{
+				Name:     record.Name,
+				Provider: providerName,
+				RPCURL:   record.RPCURL,
+			})
+		}
+		ui.Header(out, "Reset")
+		ui.Fields(out,
+			ui.Field{Label: "Validator", Value: record.Name},
+			ui.Field{Label: "Provider", Value: providerName},
+			ui.Field{Label: "RPC URL", Value: record.RPCURL},
+		)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(resetCmd)
+
+	resetCmd.Flags().StringVar(&resetName, "name", "", "Deployment name (defaults to last deployment from local state)")
+	resetCmd.Flags().BoolVar(&resetYes, "yes", false, "Skip interactive confirmation")
+	resetCmd.Flags().DurationVar(&resetTimeout, "timeout", 5*time.Minute, "How long to wait for the validator to come back healthy")
+}
+
+// waitForFreshLedger polls getGenesisHash until it differs from
+// genesisBefore. solana-test-validator creates a new genesis block every time
+// it starts on an empty ledger, so a new hash means the wipe took effect. When
+// genesisBefore is unknown it returns immediately.
+func waitForFreshLedger(ctx context.Context, rpcURL, genesisBefore string) error {
+	if genesisBefore == "" {
+		return nil
+	}
+	for {
+		var genesis string
+		if err := rpcCall(ctx, rpcURL, "getGenesisHash", nil, &genesis); err == nil && genesis != "" && genesis != genesisBefore {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("validator did not restart from genesis (genesis hash is still %s): %w", genesisBefore, ctx.Err())
+		case <-time.After(resetPollInterval):
+		}
+	}
+}
+
+func confirmReset(cmd *cobra.Command, name string) (bool, error) {
+	fmt.Fprintf(progressOutput(cmd), "this will wipe the ledger of '%s'. continue? (y/N): ", name)
+	reader := bufio.NewReader(cmd.InOrStdin())
+	line, err := reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("read confirmation: %w", err)
+	}
+
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes", nil
+}
@@ -17,10 +17,13 @@ var version = "dev"
 
 var rootCmd = &cobra.Command{
 	Use:          "sol-cloud",
-	Short:        "Deploy Solana validators to Fly.io or Railway",
-	Long:         "sol-cloud deploys and manages Solana validator environments on Fly.io or Railway.",
+	Short:        "Deploy Solana validators to Fly.io, Railway, or local Docker",
+	Long:         "sol-cloud deploys and manages Solana validator environments on Fly.io, Railway, or a local Docker daemon.",
 	Version:      version,
 	SilenceUsage: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return validateOutputFormat()
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if !ui.IsTerminal(cmd.InOrStdin()) || !ui.IsTerminal(cmd.OutOrStdout()) {
 			return cmd.Help()
@@ -40,6 +43,7 @@ func init() {
 	cobra.OnInitialize(initConfig)
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is hidden per-project config)")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", outputText, "Output format: text, json, or yaml (progress goes to stderr for json/yaml)")
 }
 
 func initConfig() {
@@ -0,0 +1,530 @@
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	appconfig "github.com/CharlieAIO/sol-cloud/internal/config"
+	"github.com/CharlieAIO/sol-cloud/internal/providers"
+	"github.com/CharlieAIO/sol-cloud/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+const snapshotPollInterval = 3 * time.Second
+
+var (
+	snapshotCreateName     string
+	snapshotCreateTimeout  time.Duration
+	snapshotRestoreTo      string
+	snapshotRestoreYes     bool
+	snapshotRestoreTimeout time.Duration
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Copy a validator ledger to or from a local archive",
+	Long: `Download the ledger of a running validator, or replace it with one downloaded
+earlier. Archives are gzipped tarballs of /var/lib/solana/ledger, written to
+.sol-cloud/snapshots/.
+
+Files move through the deployment's /sol-cloud/snapshot/ endpoint, which needs
+an API key (` + "`sol-cloud keys create`" + `) and a deploy made after the key was created.`,
+}
+
+var snapshotCreateCmd = &cobra.Command{
+	Use:   "create [name]",
+	Short: "Download the ledger of a running validator",
+	Long: `Ask the validator container to archive its ledger and download the archive into
+.sol-cloud/snapshots/. The validator is paused while the archive is written, so
+RPC requests stall for a few seconds on large ledgers. The container checks for
+requests every LEDGER_MONITOR_INTERVAL_SECONDS (30s by default).
+
+Needs an API key (` + "`sol-cloud keys create`" + `) and a deploy made after the key was
+created; deployments without keys do not serve the snapshot endpoint.`,
+	Example: `  sol-cloud snapshot create
+  sol-cloud snapshot create my-validator`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.TrimSpace(snapshotCreateName)
+		if name == "" && len(args) > 0 {
+			name = strings.TrimSpace(args[0])
+		}
+		if snapshotCreateTimeout <= 0 {
+			return fmt.Errorf("--timeout must be > 0")
+		}
+
+		projectDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("get working directory: %w", err)
+		}
+		state, err := appconfig.LoadState(projectDir)
+		if err != nil {
+			return fmt.Errorf("load local deployment state: %w", err)
+		}
+		record, err := resolveStatusRecord(state, name)
+		if err != nil {
+			return err
+		}
+		client, err := newSnapshotClient(record.RPCURL)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), snapshotCreateTimeout)
+		defer cancel()
+
+		progress := ui.NewProgress(progressOutput(cmd), 4)
+		progress.Start("Requesting ledger snapshot")
+		for _, file := range []string{"ledger.tar.gz", "ledger.error"} {
+			if err := client.remove(ctx, file); err != nil {
+				progress.Fail("Snapshot failed")
+				return err
+			}
+		}
+		if err := client.put(ctx, "request", strings.NewReader(""), 0); err != nil {
+			progress.Fail("Snapshot failed")
+			return err
+		}
+
+		progress.Step("Waiting for the validator to archive its ledger")
+		if err := client.waitFor(ctx, "ledger.tar.gz", true, "ledger.error"); err != nil {
+			progress.Fail("Snapshot failed")
+			return err
+		}
+
+		progress.Step("Downloading snapshot")
+		createdAt := time.Now().UTC()
+		path := filepath.Join(projectDir, ".sol-cloud", "snapshots",
+			fmt.Sprintf("%s-%s.tar.gz", record.Name, createdAt.Format("20060102T150405Z")))
+		size, digest, err := client.download(ctx, "ledger.tar.gz", path)
+		if err != nil {
+			progress.Fail("Snapshot failed")
+			return err
+		}
+
+		progress.Step("Removing the archive from the validator")
+		if err := client.remove(ctx, "ledger.tar.gz"); err != nil {
+			progress.Fail("Snapshot failed")
+			return err
+		}
+		progress.Success("Snapshot downloaded")
+
+		doc := snapshotDocument{
+			Name:      record.Name,
+			File:      path,
+			Size:      size,
+			SHA256:    digest,
+			CreatedAt: createdAt,
+		}
+		out := cmd.OutOrStdout()
+		if structuredOutput() {
+			return writeOutputDocument(out, "snapshot", doc)
+		}
+		ui.Header(out, "Snapshot")
+		ui.Fields(out,
+			ui.Field{Label: "Validator", Value: record.Name},
+			ui.Field{Label: "File", Value: path},
+			ui.Field{Label: "Size", Value: formatBytes(size)},
+			ui.Field{Label: "SHA-256", Value: digest},
+			ui.Field{Label: "Restore", Value: "sol-cloud snapshot restore " + path + " --to <name>"},
+		)
+		return nil
+	},
+}
+
+var snapshotRestoreCmd = &cobra.Command{
+	Use:   "restore <file>",
+	Short: "Replace a validator's ledger with a downloaded snapshot",
+	Long: `Upload a snapshot archive to a running validator. This restarts the running
+validator: the container stops it, replaces its ledger with the archive, and
+starts it again on the restored ledger without --reset. The target must already
+be deployed; the archive is not loaded before its first boot. Startup hooks
+(program deploy, airdrops) run again. Programs recorded by ` + "`sol-cloud program upgrade`" + `
+are cleared for the target.
+
+Needs an API key (` + "`sol-cloud keys create`" + `) and a deploy made after the key was
+created; deployments without keys do not serve the snapshot endpoint.`,
+	Example: `  sol-cloud snapshot restore .sol-cloud/snapshots/qa-20260101T120000Z.tar.gz --to qa-fresh
+  sol-cloud snapshot restore ledger.tar.gz --to my-validator --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.TrimSpace(snapshotRestoreTo)
+		if name == "" {
+			return errors.New("--to is required")
+		}
+		if snapshotRestoreTimeout <= 0 {
+			return fmt.Errorf("--timeout must be > 0")
+		}
+		path := strings.TrimSpace(args[0])
+		size, err := checkSnapshotArchive(path)
+		if err != nil {
+			return err
+		}
+
+		projectDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("get working directory: %w", err)
+		}
+		state, err := appconfig.LoadState(projectDir)
+		if err != nil {
+			return fmt.Errorf("load local deployment state: %w", err)
+		}
+		record, err := resolveStatusRecord(state, name)
+		if err != nil {
+			return err
+		}
+		providerName := strings.TrimSpace(record.Provider)
+		if providerName == "" {
+			providerName = "fly"
+		}
+		client, err := newSnapshotClient(record.RPCURL)
+		if err != nil {
+			return err
+		}
+
+		if !snapshotRestoreYes {
+			confirmed, confirmErr := confirmReset(cmd, record.Name)
+			if confirmErr != nil {
+				return confirmErr
+			}
+			if !confirmed {
+				fmt.Fprintln(progressOutput(cmd), "restore cancelled")
+				return nil
+			}
+		}
+
+		progress := ui.NewProgress(progressOutput(cmd), 4)
+		progress.Start("Saving restore operation state")
+		operation, err := state.StartOperation("snapshot-restore", record.Name, providerName, "restoring "+filepath.Base(path))
+		if err != nil {
+			progress.Fail("Restore failed")
+			return fmt.Errorf("start restore operation state: %w", err)
+		}
+		if err := appconfig.SaveState(projectDir, state); err != nil {
+			progress.Fail("Restore failed")
+			return fmt.Errorf("save restore operation state: %w", err)
+		}
+		fail := func(err error) error {
+			progress.Fail("Restore failed")
+			_ = state.FinishOperation(operation.ID, "failed", err.Error())
+			_ = appconfig.SaveState(projectDir, state)
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), snapshotRestoreTimeout)
+		defer cancel()
+
+		progress.Step(fmt.Sprintf("Uploading %s", formatBytes(size)))
+		if err := client.remove(ctx, "restore.error"); err != nil {
+			return fail(err)
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return fail(fmt.Errorf("open snapshot: %w", err))
+		}
+		err = client.put(ctx, "restore.tar.gz", file, size)
+		file.Close()
+		if err != nil {
+			return fail(err)
+		}
+
+		progress.Step("Waiting for the validator to load the snapshot")
+		if err := client.waitFor(ctx, "restore.tar.gz", false, "restore.error"); err != nil {
+			return fail(err)
+		}
+
+		progress.Step("Waiting for RPC health")
+		rpcURL := authorizedRPCURL(record.RPCURL)
+		if err := providers.WaitForRPCHealthy(ctx, rpcURL, snapshotRestoreTimeout, resetPollInterval); err != nil {
+			return fail(err)
+		}
+		// The restore step reports its own failure before the validator restarts
+		// on a fresh ledger, so check again once it is up.
+		if message, failed, err := client.read(ctx, "restore.error"); err != nil {
+			return fail(err)
+		} else if failed {
+			return fail(fmt.Errorf("validator could not unpack the snapshot and started from a fresh ledger: %s", message))
+		}
+
+		state.ClearPrograms(record.Name)
+		if err := state.FinishOperation(operation.ID, "succeeded", "restored "+filepath.Base(path)); err != nil {
+			progress.Fail("Restore failed")
+			return fmt.Errorf("finish restore operation state: %w", err)
+		}
+		if err := appconfig.SaveState(projectDir, state); err != nil {
+			progress.Fail("Restore failed")
+			return fmt.Errorf("save local deployment state: %w", err)
+		}
+		progress.Success("Snapshot restored")
+
+		var slot uint64
+		_ = rpcCall(ctx, rpcURL, "getSlot", nil, &slot)
+
+		out := cmd.OutOrStdout()
+		if structuredOutput() {
+			return writeOutputDocument(out, "snapshot_restore", snapshotRestoreDocument{
+				Name:   record.Name,
+				File:   path,
+				Size:   size,
+				RPCURL: record.RPCURL,
+				Slot:   slot,
+			})
+		}
+		slotText := ""
+		if slot > 0 {
+			slotText = fmt.Sprintf("%d", slot)
+		}
+		ui.Header(out, "Snapshot Restored")
+		ui.Fields(out,
+			ui.Field{Label: "Validator", Value: record.Name},
+			ui.Field{Label: "File", Value: path},
+			ui.Field{Label: "Slot", Value: slotText},
+			ui.Field{Label: "RPC URL", Value: record.RPCURL},
+		)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+	snapshotCmd.AddCommand(snapshotCreateCmd, snapshotRestoreCmd)
+
+	snapshotCreateCmd.Flags().StringVar(&snapshotCreateName, "name", "", "Deployment name (defaults to last deployment from local state)")
+	snapshotCreateCmd.Flags().DurationVar(&snapshotCreateTimeout, "timeout", 15*time.Minute, "How long to wait for the archive and download")
+
+	snapshotRestoreCmd.Flags().StringVar(&snapshotRestoreTo, "to", "", "Deployment whose ledger is replaced")
+	snapshotRestoreCmd.Flags().BoolVar(&snapshotRestoreYes, "yes", false, "Skip interactive confirmation")
+	snapshotRestoreCmd.Flags().DurationVar(&snapshotRestoreTimeout, "timeout", 15*time.Minute, "How long to wait for the upload and for the validator to come back healthy")
+}
+
+type snapshotDocument struct {
+	Name      string    `json:"name" yaml:"name"`
+	File      string    `json:"file" yaml:"file"`
+	Size      int64     `json:"size" yaml:"size"`
+	SHA256    string    `json:"sha256" yaml:"sha256"`
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+}
+
+type snapshotRestoreDocument struct {
+	Name   string `json:"name" yaml:"name"`
+	File   string `json:"file" yaml:"file"`
+	Size   int64  `json:"size" yaml:"size"`
+	RPCURL string `json:"rpc_url" yaml:"rpc_url"`
+	Slot   uint64 `json:"slot,omitempty" yaml:"slot,omitempty"`
+}
+
+// snapshotClient reads and writes the files behind a deployment's
+// /sol-cloud/snapshot/ endpoint, which nginx serves over WebDAV.
+type snapshotClient struct {
+	baseURL string
+	header  string
+	key     string
+	http    *http.Client
+}
+
+func newSnapshotClient(rpcURL string) (*snapshotClient, error) {
+	cfg, err := loadAccessConfig()
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.Enabled() {
+		return nil, errors.New("snapshots need an API key because the snapshot endpoint can replace the ledger; run `sol-cloud keys create <name>` and `sol-cloud deploy` first")
+	}
+	return &snapshotClient{
+		baseURL: strings.TrimRight(strings.TrimSpace(rpcURL), "/") + "/sol-cloud/snapshot/",
+		header:  cfg.Header,
+		key:     cfg.Keys[0].Key,
+		http:    &http.Client{},
+	}, nil
+}
+
+func (c *snapshotClient) do(ctx context.Context, method, file string, body io.Reader, size int64) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+file, body)
+	if err != nil {
+		return nil, fmt.Errorf("create snapshot request: %w", err)
+	}
+	if body != nil {
+		req.ContentLength = size
+	}
+	req.Header.Set(c.header, c.key)
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot %s %s: %w", method, file, err)
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+		return nil, errors.New("snapshot endpoint rejected the API key; run `sol-cloud deploy` to apply the current keys")
+	}
+	return resp, nil
+}
+
+// put uploads body as file. Deployments made before snapshots existed pass the
+// request through to the RPC port, which never answers 201 or 204.
+func (c *snapshotClient) put(ctx context.Context, file string, body io.Reader, size int64) error {
+	resp, err := c.do(ctx, http.MethodPut, file, body, size)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("upload %s: status %d; redeploy with `sol-cloud deploy` to enable snapshots", file, resp.StatusCode)
+	}
+	return nil
+}
+
+func (c *snapshotClient) remove(ctx context.Context, file string) error {
+	resp, err := c.do(ctx, http.MethodDelete, file, nil, 0)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("remove %s: status %d; redeploy with `sol-cloud deploy` to enable snapshots", file, resp.StatusCode)
+	}
+	return nil
+}
+
+// read returns the contents of a small file and whether it exists.
+func (c *snapshotClient) read(ctx context.Context, file string) (string, bool, error) {
+	resp, err := c.do(ctx, http.MethodGet, file, nil, 0)
+	if err != nil {
+		return "", false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return "", false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", false, fmt.Errorf("read %s: status %d", file, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return "", false, fmt.Errorf("read %s: %w", file, err)
+	}
+	return strings.TrimSpace(string(body)), true, nil
+}
+
+func (c *snapshotClient) exists(ctx context.Context, file string) (bool, error) {
+	resp, err := c.do(ctx, http.MethodHead, file, nil, 0)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("check %s: status %d", file, resp.StatusCode)
+	}
+}
+
+// waitFor polls until file exists (or is gone, when present is false). The
+// entrypoint writes errorFile instead when it cannot finish.
+func (c *snapshotClient) waitFor(ctx context.Context, file string, present bool, errorFile string) error {
+	for {
+		message, failed, err := c.read(ctx, errorFile)
+		if err != nil {
+			return err
+		}
+		if failed {
+			return fmt.Errorf("validator reported: %s", message)
+		}
+		found, err := c.exists(ctx, file)
+		if err != nil {
+			return err
+		}
+		if found == present {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("validator did not pick up the snapshot request: %w", ctx.Err())
+		case <-time.After(snapshotPollInterval):
+		}
+	}
+}
+
+// download streams file to path and returns its size and SHA-256.
+func (c *snapshotClient) download(ctx context.Context, file, path string) (int64, string, error) {
+	resp, err := c.do(ctx, http.MethodGet, file, nil, 0)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, "", fmt.Errorf("download %s: status %d", file, resp.StatusCode)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return 0, "", fmt.Errorf("create snapshots dir: %w", err)
+	}
+	partial := path + ".partial"
+	out, err := os.Create(partial)
+	if err != nil {
+		return 0, "", fmt.Errorf("create snapshot file: %w", err)
+	}
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(out, hash), resp.Body)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(partial)
+		return 0, "", fmt.Errorf("download %s: %w", file, err)
+	}
+	if err := os.Rename(partial, path); err != nil {
+		return 0, "", fmt.Errorf("save snapshot file: %w", err)
+	}
+	return size, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// checkSnapshotArchive rejects files that are not gzipped tarballs before they
+// are uploaded, and returns the file size.
+func checkSnapshotArchive(path string) (int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("open snapshot: %w", err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("stat snapshot: %w", err)
+	}
+	if info.IsDir() {
+		return 0, fmt.Errorf("%s is a directory; pass a .tar.gz archive", path)
+	}
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a gzipped tar archive: %w", path, err)
+	}
+	defer gz.Close()
+	if _, err := tar.NewReader(gz).Next(); err != nil {
+		return 0, fmt.Errorf("%s is not a gzipped tar archive: %w", path, err)
+	}
+	return info.Size(), nil
+}
+
+func formatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
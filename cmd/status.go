@@ -55,7 +55,7 @@ var statusCmd = &cobra.Command{
 		}
 
 		out := cmd.OutOrStdout()
-		progress := ui.NewProgress(out, 2)
+		progress := ui.NewProgress(progressOutput(cmd), 2)
 		progress.Start("Checking provider status")
 
 		providerState := "unknown"
@@ -81,7 +81,7 @@ var statusCmd = &cobra.Command{
 		statusCtx, cancel := context.WithTimeout(cmd.Context(), statusTimeout)
 		defer cancel()
 
-		metrics, err := fetchRPCMetrics(statusCtx, record.RPCURL)
+		metrics, err := fetchRPCMetrics(statusCtx, authorizedRPCURL(record.RPCURL))
 		if err == nil {
 			slot = fmt.Sprintf("%d", metrics.Slot)
 			tps = fmt.Sprintf("%.2f", metrics.TPS)
@@ -92,6 +92,8 @@ var statusCmd = &cobra.Command{
 			healthText = "timeout"
 		}
 
+		startup, startupErr := fetchStartupReport(statusCtx, record.RPCURL)
+
 		statusLabel := providerState
 		if strings.EqualFold(providerState, "running") {
 			statusLabel = "running"
@@ -99,28 +101,67 @@ var statusCmd = &cobra.Command{
 
 		progress.Success("Status loaded")
 
+		if structuredOutput() {
+			doc := statusDocument{
+				Name:            record.Name,
+				Provider:        record.Provider,
+				State:           statusLabel,
+				Health:          healthText,
+				RPCURL:          record.RPCURL,
+				WebSocketURL:    record.WebSocketURL,
+				DashboardURL:    record.DashboardURL,
+				ProviderWarning: providerErrText,
+			}
+			if metrics != nil {
+				doc.Slot = &metrics.Slot
+				doc.TPS = &metrics.TPS
+			}
+			if operation, ok := state.LatestOperationFor(record.Name); ok {
+				doc.LastOperation = toOperationDocument(operation)
+			}
+			for _, program := range record.Programs {
+				doc.Programs = append(doc.Programs, toProgramDocument(program))
+			}
+			doc.Startup = startup
+			return writeOutputDocument(out, "status", doc)
+		}
+
 		operationText := ""
 		if operation, ok := state.LatestOperationFor(record.Name); ok {
 			when := operation.UpdatedAt.Local().Format(time.RFC3339)
-			operationText = fmt.Sprintf("%s %s at %s", operation.Type, operation.Status, when)
+			operationType := operation.Type
+			if step, ok := strings.CutPrefix(operationType, "watch-"); ok {
+				operationType = "watch: " + step
+			}
+			operationText = fmt.Sprintf("%s %s at %s", operationType, operation.Status, when)
 			if operation.Message != "" {
 				operationText += " (" + operation.Message + ")"
 			}
 		}
 
-		ui.Header(out, "Status")
-		ui.Fields(out,
-			ui.Field{Label: "Validator", Value: record.Name},
-			ui.Field{Label: "Provider", Value: record.Provider},
-			ui.Field{Label: "State", Value: statusLabel},
-			ui.Field{Label: "Health", Value: healthText},
-			ui.Field{Label: "Slot", Value: slot},
-			ui.Field{Label: "TPS", Value: tps},
+		fields := []ui.Field{
+			{Label: "Validator", Value: record.Name},
+			{Label: "Provider", Value: record.Provider},
+			{Label: "State", Value: statusLabel},
+			{Label: "Health", Value: healthText},
+			{Label: "Slot", Value: slot},
+			{Label: "TPS", Value: tps},
+		}
+		// Skip the startup rows when the endpoint is down altogether; Health
+		// already says so.
+		if startup != nil || metrics != nil {
+			fields = append(fields, startupFields(startup, startupErr)...)
+		}
+		fields = append(fields,
 			ui.Field{Label: "RPC", Value: record.RPCURL},
 			ui.Field{Label: "WebSocket", Value: record.WebSocketURL},
 			ui.Field{Label: "Dashboard", Value: record.DashboardURL},
 			ui.Field{Label: "Last operation", Value: operationText},
 		)
+		fields = append(fields, programFields(record.Programs)...)
+
+		ui.Header(out, "Status")
+		ui.Fields(out, fields...)
 		if providerErrText != "" {
 			ui.Fields(out, ui.Field{Label: "Provider warning", Value: providerErrText})
 		}
@@ -194,6 +235,120 @@ func fetchRPCMetrics(ctx context.Context, rpcURL string) (*rpcMetrics, error) {
 	return &rpcMetrics{Slot: slot, TPS: tps}, nil
 }
 
+// fetchStartupReport reads the entrypoint's startup report through nginx. It
+// returns nil without an error for deployments that predate the report.
+func fetchStartupReport(ctx context.Context, rpcURL string) (*startupReport, error) {
+	url := authorizedRPCURL(strings.TrimRight(strings.TrimSpace(rpcURL), "/") + "/sol-cloud/startup.json")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create startup report request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("startup report request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusMethodNotAllowed:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("startup report status %d", resp.StatusCode)
+	}
+	var report startupReport
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&report); err != nil {
+		// Older images proxy unknown paths to the RPC port, which does not
+		// answer with a report.
+		return nil, nil
+	}
+	if report.Status == "" {
+		return nil, nil
+	}
+	return &report, nil
+}
+
+// startupFields summarizes a startup report for `status`, with one extra row
+// per clone, program deploy, or airdrop that did not succeed.
+func startupFields(report *startupReport, err error) []ui.Field {
+	if err != nil {
+		return []ui.Field{{Label: "Startup", Value: "unavailable: " + err.Error()}}
+	}
+	if report == nil {
+		return []ui.Field{{Label: "Startup", Value: "n/a (redeploy to enable the startup report)"}}
+	}
+
+	status := report.Status
+	if report.Message != "" {
+		status += ": " + report.Message
+	}
+	if report.ValidatorStartedAt != nil {
+		status += fmt.Sprintf(" (validator started %s)", report.ValidatorStartedAt.Local().Format(time.RFC3339))
+	}
+	ledger := "fresh"
+	if report.Ledger.Preserved {
+		ledger = "preserved"
+	}
+	if count := len(report.Ledger.Resets); count > 0 {
+		last := report.Ledger.Resets[count-1]
+		ledger += fmt.Sprintf("; %d reset(s), last %s at %s", count, last.Reason, last.Time.Local().Format(time.RFC3339))
+	}
+
+	var issues []ui.Field
+	clones := map[string]int{}
+	for _, clone := range report.Clones {
+		clones[clone.Result]++
+		if clone.Result == "missing" || clone.Result == "skipped" {
+			issues = append(issues, ui.Field{Label: "Clone " + clone.Result, Value: joinNonEmpty(clone.Address, clone.Message)})
+		}
+	}
+	programs := map[string]int{}
+	for _, program := range report.Programs {
+		programs[program.Result]++
+		if program.Result == "missing" || program.Result == "failed" {
+			issues = append(issues, ui.Field{Label: "Program " + program.Result, Value: joinNonEmpty(program.ProgramID, program.SOPath, program.Message)})
+		}
+	}
+	airdrops := map[string]int{}
+	for _, airdrop := range report.Airdrops {
+		airdrops[airdrop.Result]++
+		if airdrop.Result != "confirmed" {
+			issues = append(issues, ui.Field{Label: "Airdrop " + airdrop.Result, Value: joinNonEmpty(fmt.Sprintf("%d SOL to %s", airdrop.Amount, airdrop.Address), airdrop.Message)})
+		}
+	}
+
+	fields := []ui.Field{
+		{Label: "Startup", Value: status},
+		{Label: "Solana", Value: report.SolanaVersion},
+		{Label: "Ledger", Value: ledger},
+		{Label: "Clones", Value: resultCounts(clones, "loaded", "requested", "missing", "skipped")},
+		{Label: "Programs", Value: resultCounts(programs, "deployed", "requested", "missing", "failed")},
+		{Label: "Airdrops", Value: resultCounts(airdrops, "confirmed", "failed", "skipped")},
+	}
+	return append(fields, issues...)
+}
+
+// resultCounts renders counts such as "3 loaded, 1 missing" in the given order.
+func resultCounts(counts map[string]int, order ...string) string {
+	var parts []string
+	for _, result := range order {
+		if counts[result] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[result], result))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func joinNonEmpty(values ...string) string {
+	var parts []string
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, ": ")
+}
+
 func rpcCall(ctx context.Context, rpcURL, method string, params any, result any) error {
 	if strings.TrimSpace(rpcURL) == "" {
 		return errors.New("rpc url is required")
@@ -2,21 +2,27 @@ package cmd
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/signal"
+	"sort"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	appconfig "github.com/CharlieAIO/sol-cloud/internal/config"
+	"github.com/CharlieAIO/sol-cloud/internal/metrics"
 	"github.com/CharlieAIO/sol-cloud/internal/monitor"
+	"github.com/CharlieAIO/sol-cloud/internal/notify"
 	"github.com/CharlieAIO/sol-cloud/internal/providers"
 	"github.com/CharlieAIO/sol-cloud/internal/ui"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 var (
@@ -26,29 +32,57 @@ var (
 	watchMaxRestarts     int
 	watchRestartCooldown time.Duration
 	watchAutoRestart     bool
+	watchNotifyOn        []string
+	watchUnreachable     int
+	watchMetricsAddr     string
+	watchAll             bool
+	watchRecovery        []string
+	watchEscalateAfter   int
+	watchEscalateWindow  time.Duration
 )
 
 var watchCmd = &cobra.Command{
-	Use:   "watch [name]",
-	Short: "Watch validator and restart if stuck",
+	Use:   "watch [name...]",
+	Short: "Watch validators and restart if stuck",
 	Long: `Monitor a deployed validator for stuck slot progression and automatically restart when detected.
 
 The watcher polls the validator's RPC endpoint at regular intervals to check slot progression.
 If the slot hasn't changed for longer than the stuck threshold, it triggers a restart.
 
-By default, the watcher requires user confirmation before restarting. Use --auto-restart to skip prompts.`,
+By default, the watcher requires user confirmation before restarting. Use --auto-restart to skip prompts.
+
+Pass several names, or --all for every deployment in local state, to watch them
+from one process. Each deployment gets its own watcher with its own slot
+history, restart budget, and cooldown, and its output lines are prefixed with
+the deployment name.
+
+Alerts for stuck detection, restarts, max restarts, an unreachable RPC, and
+recovery can be sent to webhook, Slack, Discord, or PagerDuty targets listed
+under watch.notify in the project config.`,
 	Example: `  # Watch the last deployed validator (interactive mode)
   sol-cloud watch
 
   # Watch a specific validator with automatic restarts
   sol-cloud watch my-validator --auto-restart
 
+  # Watch every deployment in local state
+  sol-cloud watch --all --auto-restart
+
+  # Watch two validators
+  sol-cloud watch squad-a squad-b --auto-restart
+
   # Aggressive monitoring for production
   sol-cloud watch --auto-restart --stuck-threshold 2m --check-interval 20s
 
+  # Only alert when a restart fails or the restart budget runs out
+  sol-cloud watch --auto-restart --notify-on restart_failed,max_restarts
+
+  # Serve Prometheus metrics on :9464/metrics
+  sol-cloud watch --auto-restart --metrics-addr :9464
+
   # Run in background
   nohup sol-cloud watch --auto-restart > watcher.log 2>&1 &`,
-	Args: cobra.MaximumNArgs(1),
+	Args: cobra.ArbitraryArgs,
 	RunE: runWatch,
 }
 
@@ -56,17 +90,29 @@ func init() {
 	rootCmd.AddCommand(watchCmd)
 
 	watchCmd.Flags().StringVar(&watchName, "name", "", "Deployment name (defaults to last deployment)")
+	watchCmd.Flags().BoolVar(&watchAll, "all", false, "Watch every deployment in local state")
 	watchCmd.Flags().DurationVar(&watchCheckInterval, "check-interval", 30*time.Second, "Polling frequency for slot checks")
 	watchCmd.Flags().DurationVar(&watchStuckThreshold, "stuck-threshold", 3*time.Minute, "Duration before slot is considered stuck")
 	watchCmd.Flags().IntVar(&watchMaxRestarts, "max-restarts", 0, "Maximum restart attempts (0 = unlimited)")
 	watchCmd.Flags().DurationVar(&watchRestartCooldown, "restart-cooldown", 2*time.Minute, "Minimum time between restarts")
 	watchCmd.Flags().BoolVar(&watchAutoRestart, "auto-restart", false, "Skip confirmation prompts and restart automatically")
+	watchCmd.Flags().StringSliceVar(&watchRecovery, "recovery", []string{recoveryRestart}, "Recovery ladder, mildest first ("+strings.Join(recoverySteps, ", ")+")")
+	watchCmd.Flags().IntVar(&watchEscalateAfter, "escalate-after", 3, "Restarts inside --escalate-window before moving to the next --recovery step")
+	watchCmd.Flags().DurationVar(&watchEscalateWindow, "escalate-window", 30*time.Minute, "Window for counting restarts toward --escalate-after")
+	watchCmd.Flags().StringSliceVar(&watchNotifyOn, "notify-on", nil, "Events to send to watch.notify targets, replacing watch.notify.events ("+strings.Join(notify.Events, ", ")+")")
+	watchCmd.Flags().StringVar(&watchMetricsAddr, "metrics-addr", "", "Serve Prometheus metrics at /metrics on this address (e.g. :9464)")
+	watchCmd.Flags().IntVar(&watchUnreachable, "unreachable-checks", 0, "Failed RPC checks in a row before rpc_unreachable is sent (default watch.notify.unreachable_checks or 3)")
 }
 
 func runWatch(cmd *cobra.Command, args []string) error {
-	name := strings.TrimSpace(watchName)
-	if name == "" && len(args) > 0 {
-		name = strings.TrimSpace(args[0])
+	var names []string
+	for _, name := range append([]string{watchName}, args...) {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	if watchAll && len(names) > 0 {
+		return errors.New("--all cannot be combined with deployment names")
 	}
 
 	projectDir, err := os.Getwd()
@@ -79,63 +125,249 @@ func runWatch(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("load local deployment state: %w", err)
 	}
 
-	record, err := resolveStatusRecord(state, name)
+	records, err := resolveWatchRecords(state, names, watchAll)
+	if err != nil {
+		return err
+	}
+	ladder, err := parseRecoveryLadder(watchRecovery)
+	if err != nil {
+		return err
+	}
+	if watchEscalateAfter < 1 {
+		return errors.New("--escalate-after must be >= 1")
+	}
+	if watchEscalateWindow <= 0 {
+		return errors.New("--escalate-window must be > 0")
+	}
+	accessCfg, err := loadAccessConfig()
+	if err != nil {
+		return err
+	}
+	notifyCfg, err := loadWatchNotifyConfig(cmd)
 	if err != nil {
 		return err
 	}
-	if strings.TrimSpace(record.Provider) == "" {
-		record.Provider = "fly"
+	notifier, err := notify.New(notifyCfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	var collector *watchMetrics
+	metricsURL := ""
+	if addr := strings.TrimSpace(watchMetricsAddr); addr != "" {
+		collector = newWatchMetrics()
+		metricsURL, err = collector.serve(ctx, addr)
+		if err != nil {
+			return err
+		}
 	}
 
 	out := cmd.OutOrStdout()
-	ui.Header(out, "Watch")
+	var events *outputStream
+	if structuredOutput() {
+		events = newOutputStream(out, "watch_event")
+		out = cmd.ErrOrStderr()
+	}
+	maxRestarts := "unlimited"
 	if watchMaxRestarts > 0 {
-		ui.Fields(out,
-			ui.Field{Label: "Validator", Value: record.Name},
-			ui.Field{Label: "RPC", Value: record.RPCURL},
-			ui.Field{Label: "Check interval", Value: watchCheckInterval.String()},
-			ui.Field{Label: "Stuck threshold", Value: watchStuckThreshold.String()},
-			ui.Field{Label: "Max restarts", Value: fmt.Sprintf("%d", watchMaxRestarts)},
-			ui.Field{Label: "Restart cooldown", Value: watchRestartCooldown.String()},
-			ui.Field{Label: "Auto-restart", Value: fmt.Sprintf("%t", watchAutoRestart)},
+		maxRestarts = fmt.Sprintf("%d", watchMaxRestarts)
+	}
+	recovery := ""
+	if len(ladder) > 1 {
+		recovery = fmt.Sprintf("%s (escalate after %d in %s)", strings.Join(ladder, " -> "), watchEscalateAfter, watchEscalateWindow)
+	}
+	var fields []ui.Field
+	if len(records) == 1 {
+		fields = append(fields,
+			ui.Field{Label: "Validator", Value: records[0].Name},
+			ui.Field{Label: "RPC", Value: records[0].RPCURL},
 		)
 	} else {
-		ui.Fields(out,
-			ui.Field{Label: "Validator", Value: record.Name},
-			ui.Field{Label: "RPC", Value: record.RPCURL},
-			ui.Field{Label: "Check interval", Value: watchCheckInterval.String()},
-			ui.Field{Label: "Stuck threshold", Value: watchStuckThreshold.String()},
-			ui.Field{Label: "Max restarts", Value: "unlimited"},
-			ui.Field{Label: "Restart cooldown", Value: watchRestartCooldown.String()},
-			ui.Field{Label: "Auto-restart", Value: fmt.Sprintf("%t", watchAutoRestart)},
-		)
+		for _, record := range records {
+			fields = append(fields, ui.Field{Label: record.Name, Value: record.Provider + "  " + record.RPCURL})
+		}
 	}
+	fields = append(fields,
+		ui.Field{Label: "Check interval", Value: watchCheckInterval.String()},
+		ui.Field{Label: "Stuck threshold", Value: watchStuckThreshold.String()},
+		ui.Field{Label: "Max restarts", Value: maxRestarts},
+		ui.Field{Label: "Restart cooldown", Value: watchRestartCooldown.String()},
+		ui.Field{Label: "Auto-restart", Value: fmt.Sprintf("%t", watchAutoRestart)},
+		ui.Field{Label: "Recovery", Value: recovery},
+		ui.Field{Label: "Notify", Value: notifier.Summary()},
+		ui.Field{Label: "Metrics", Value: metricsURL},
+	)
+	ui.Header(out, "Watch")
+	ui.Fields(out, fields...)
 	fmt.Fprintln(out)
 	fmt.Fprintln(out, "Press Ctrl+C to stop watching")
 	fmt.Fprintln(out)
 
-	ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
-	defer cancel()
+	// Watchers share the terminal: prompts take turns on one stdin reader, so
+	// input buffered by one prompt is not lost to the next, and with more than
+	// one deployment each line is prefixed with the deployment name.
+	var outputMu, promptMu, stateMu sync.Mutex
+	input := bufio.NewReader(cmd.InOrStdin())
+	watchers := make([]*ValidatorWatcher, 0, len(records))
+	for _, record := range records {
+		watchProvider, err := providers.NewProvider(record.Provider)
+		if err != nil {
+			return fmt.Errorf("create provider for watch %s: %w", record.Name, err)
+		}
+		volumeSize := record.VolumeSize
+		if volumeSize <= 0 {
+			// Records written before the volume settings were saved.
+			volumeSize = 10
+		}
+		var watcherOut io.Writer = out
+		if len(records) > 1 {
+			watcherOut = &prefixWriter{mu: &outputMu, out: out, prefix: "[" + record.Name + "] "}
+		}
+		watchers = append(watchers, &ValidatorWatcher{
+			name:            record.Name,
+			rpcURL:          authorizedRPCURL(record.RPCURL),
+			provider:        watchProvider,
+			history:         monitor.NewSlotHistory(watchStuckThreshold),
+			checkInterval:   watchCheckInterval,
+			maxRestarts:     watchMaxRestarts,
+			restartCooldown: watchRestartCooldown,
+			autoRestart:     watchAutoRestart,
+			input:           input,
+			output:          watcherOut,
+			events:          events,
+
+			providerName:      record.Provider,
+			notifier:          notifier,
+			unreachableChecks: notifyCfg.UnreachableChecks,
+			metrics:           collector,
+			prompt:            &promptMu,
+
+			ladder:         ladder,
+			escalateAfter:  watchEscalateAfter,
+			escalateWindow: watchEscalateWindow,
+			redeploy: &providers.Config{
+				Name:           record.Name,
+				OrgSlug:        strings.TrimSpace(viper.GetString("org")),
+				Region:         firstNonEmpty(strings.TrimSpace(record.Region), strings.TrimSpace(viper.GetString("region"))),
+				ProjectDir:     projectDir,
+				Access:         accessCfg,
+				VolumeSize:     volumeSize,
+				SkipVolume:     record.SkipVolume,
+				HostPort:       recordHostPort(record),
+				ReuseArtifacts: true,
+			},
+			projectDir: projectDir,
+			stateMu:    &stateMu,
+		})
+	}
 
-	watchProvider, err := providers.NewProvider(record.Provider)
-	if err != nil {
-		return fmt.Errorf("create provider for watch: %w", err)
+	if len(watchers) == 1 {
+		return watchers[0].Run(ctx)
+	}
+	errs := make([]error, len(watchers))
+	var wg sync.WaitGroup
+	for i, watcher := range watchers {
+		wg.Add(1)
+		go func(i int, watcher *ValidatorWatcher) {
+			defer wg.Done()
+			if err := watcher.Run(ctx); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", watcher.name, err)
+			}
+		}(i, watcher)
 	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
 
-	watcher := &ValidatorWatcher{
-		name:            record.Name,
-		rpcURL:          record.RPCURL,
-		provider:        watchProvider,
-		history:         monitor.NewSlotHistory(watchStuckThreshold),
-		checkInterval:   watchCheckInterval,
-		maxRestarts:     watchMaxRestarts,
-		restartCooldown: watchRestartCooldown,
-		autoRestart:     watchAutoRestart,
-		input:           cmd.InOrStdin(),
-		output:          out,
+// parseRecoveryLadder validates --recovery.
+func parseRecoveryLadder(values []string) ([]string, error) {
+	var ladder []string
+	seen := map[string]bool{}
+	for _, value := range values {
+		step := strings.ToLower(strings.TrimSpace(value))
+		if _, ok := recoveryPrompts[step]; !ok {
+			return nil, fmt.Errorf("--recovery: unknown step %q (expected %s)", value, strings.Join(recoverySteps, ", "))
+		}
+		if seen[step] {
+			return nil, fmt.Errorf("--recovery: %s listed twice", step)
+		}
+		seen[step] = true
+		ladder = append(ladder, step)
 	}
+	if len(ladder) == 0 {
+		return nil, errors.New("--recovery needs at least one step")
+	}
+	return ladder, nil
+}
 
-	return watcher.Run(ctx)
+// resolveWatchRecords returns the deployments to watch: every deployment in
+// state with all, each named deployment, or the last deployment.
+func resolveWatchRecords(state *appconfig.State, names []string, all bool) ([]appconfig.DeploymentRecord, error) {
+	if all {
+		if len(state.Deployments) == 0 {
+			return nil, errors.New("no deployments found; run `sol-cloud deploy` first")
+		}
+		names = make([]string, 0, len(state.Deployments))
+		for name := range state.Deployments {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	if len(names) == 0 {
+		names = []string{""}
+	}
+
+	records := make([]appconfig.DeploymentRecord, 0, len(names))
+	seen := map[string]bool{}
+	for _, name := range names {
+		record, err := resolveStatusRecord(state, name)
+		if err != nil {
+			return nil, err
+		}
+		if seen[record.Name] {
+			continue
+		}
+		seen[record.Name] = true
+		if strings.TrimSpace(record.Provider) == "" {
+			record.Provider = "fly"
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// prefixWriter starts every line with prefix so several watchers can share
+// one terminal. Blank separator lines are dropped since they only make sense
+// for a single watcher.
+type prefixWriter struct {
+	mu      *sync.Mutex
+	out     io.Writer
+	prefix  string
+	midLine bool
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var buf bytes.Buffer
+	for _, line := range bytes.SplitAfter(b, []byte("\n")) {
+		if len(line) == 0 || (!p.midLine && len(line) == 1 && line[0] == '\n') {
+			continue
+		}
+		if !p.midLine {
+			buf.WriteString(p.prefix)
+		}
+		buf.Write(line)
+		p.midLine = line[len(line)-1] != '\n'
+	}
+	if _, err := p.out.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(b), nil
 }
 
 // ValidatorWatcher monitors a validator and restarts it when stuck.
@@ -148,22 +380,55 @@ type ValidatorWatcher struct {
 	maxRestarts     int
 	restartCooldown time.Duration
 	autoRestart     bool
-	input           io.Reader
+	input           *bufio.Reader
 	output          interface{ Write([]byte) (int, error) }
-
-	restartCount    int
-	lastRestartTime time.Time
+	// events, when set, receives one structured document per watch event and
+	// output only carries prompts.
+	events *outputStream
+
+	providerName      string
+	notifier          *notify.Dispatcher
+	unreachableChecks int
+	// prompt serializes restart confirmations between watchers sharing input.
+	prompt *sync.Mutex
+	// ladder lists the recovery steps in order; step indexes the current one
+	// and stepAttempts holds its successful runs inside escalateWindow.
+	ladder         []string
+	escalateAfter  int
+	escalateWindow time.Duration
+	step           int
+	stepAttempts   []time.Time
+	// redeploy is the provider config for the redeploy step. stateMu guards
+	// operation records written by watchers sharing projectDir.
+	redeploy   *providers.Config
+	projectDir string
+	stateMu    *sync.Mutex
+	// metrics, when set, is updated on every check for --metrics-addr.
+	metrics       *watchMetrics
+	providerState string
+
+	restartCount     int
+	lastRestartTime  time.Time
+	unreachableCount int
+	// stuckNotified is set once a stuck alert went out and cleared when the
+	// slot moves or a restart succeeds, so one stall sends one alert.
+	stuckNotified bool
+	// alerted is set by any notification and cleared by the recovered event
+	// sent once the slot moves again.
+	alerted bool
 }
 
 // Run starts the watch loop and blocks until context is cancelled or max restarts reached.
 func (w *ValidatorWatcher) Run(ctx context.Context) error {
+	w.metrics.init(w.labels())
+
 	ticker := time.NewTicker(w.checkInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
-			fmt.Fprintln(w.output, "\nWatcher stopped")
+			w.report(watchEvent{Event: "stopped"}, "\nWatcher stopped\n")
 			return nil
 
 		case <-ticker.C:
@@ -171,12 +436,15 @@ func (w *ValidatorWatcher) Run(ctx context.Context) error {
 				if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
 					return nil
 				}
-				fmt.Fprintf(w.output, "warn [%s] check error: %v\n", time.Now().Format("15:04:05"), err)
+				w.report(watchEvent{Event: "check_error", Message: err.Error()},
+					fmt.Sprintf("warn [%s] check error: %v\n", time.Now().Format("15:04:05"), err))
 			}
 
 			// Check if max restarts reached
 			if w.maxRestarts > 0 && w.restartCount >= w.maxRestarts {
-				fmt.Fprintf(w.output, "\nMax restarts (%d) reached, stopping watcher\n", w.maxRestarts)
+				w.report(watchEvent{Event: "max_restarts", Restarts: w.restartCount},
+					fmt.Sprintf("\nMax restarts (%d) reached, stopping watcher\n", w.maxRestarts))
+				w.notify(ctx, notify.Event{Type: notify.EventMaxRestarts, Slot: w.history.GetLatestSlot()})
 				return nil
 			}
 		}
@@ -188,72 +456,362 @@ func (w *ValidatorWatcher) checkAndRestart(ctx context.Context) error {
 	checkCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
+	w.updateProviderState(ctx)
+
 	var slot uint64
+	started := time.Now()
 	if err := rpcCall(checkCtx, w.rpcURL, "getSlot", []any{}, &slot); err != nil {
+		w.metrics.rpcFailed(w.labels())
 		// RPC unreachable - don't treat as stuck, just log warning
-		fmt.Fprintf(w.output, "warn [%s] RPC unreachable: %v\n", time.Now().Format("15:04:05"), err)
+		w.report(watchEvent{Event: "rpc_unreachable", Message: err.Error()},
+			fmt.Sprintf("warn [%s] RPC unreachable: %v\n", time.Now().Format("15:04:05"), err))
+		w.unreachableCount++
+		if w.unreachableCount == w.unreachableChecks {
+			w.notify(ctx, notify.Event{Type: notify.EventRPCUnreachable, Checks: w.unreachableCount, Message: err.Error()})
+		}
 		return nil
 	}
+	w.unreachableCount = 0
+
+	latency := time.Since(started)
 
 	// Record slot observation
 	w.history.Record(slot)
+	w.metrics.rpcSucceeded(w.labels(), slot, latency, w.history.Rate())
 
 	// Check if stuck
 	stuck, info := w.history.IsStuck()
 	if !stuck {
+		w.metrics.stuckState(w.labels(), false, 0)
+		w.stuckNotified = false
 		if w.history.HasProgressed() {
-			fmt.Fprintf(w.output, "ok   [%s] slot=%d progressing\n", time.Now().Format("15:04:05"), slot)
+			w.resetLadder()
+			w.report(watchEvent{Event: "progressing", Slot: slot},
+				fmt.Sprintf("ok   [%s] slot=%d progressing\n", time.Now().Format("15:04:05"), slot))
+			if w.alerted {
+				w.alerted = false
+				w.notify(ctx, notify.Event{Type: notify.EventRecovered, Slot: slot})
+			}
 		} else {
-			fmt.Fprintf(w.output, "wait [%s] slot=%d waiting for progression\n", time.Now().Format("15:04:05"), slot)
+			w.report(watchEvent{Event: "waiting", Slot: slot},
+				fmt.Sprintf("wait [%s] slot=%d waiting for progression\n", time.Now().Format("15:04:05"), slot))
 		}
 		return nil
 	}
 
 	// Validator is stuck
-	fmt.Fprintf(w.output, "\nalert [%s] stuck detected: %s\n", time.Now().Format("15:04:05"), info.String())
+	w.metrics.stuckState(w.labels(), true, info.Duration)
+	w.report(watchEvent{Event: "stuck", Slot: slot, Message: info.String()},
+		fmt.Sprintf("\nalert [%s] stuck detected: %s\n", time.Now().Format("15:04:05"), info.String()))
+	if !w.stuckNotified {
+		w.stuckNotified = true
+		w.notify(ctx, notify.Event{Type: notify.EventStuck, Slot: slot, Message: info.String()})
+	}
 
 	// Check cooldown
 	if !w.lastRestartTime.IsZero() {
 		elapsed := time.Since(w.lastRestartTime)
 		if elapsed < w.restartCooldown {
 			remaining := w.restartCooldown - elapsed
-			fmt.Fprintf(w.output, "wait restart cooldown active, remaining=%s\n", remaining.Round(time.Second))
+			w.report(watchEvent{Event: "restart_cooldown", Slot: slot, Message: "remaining=" + remaining.Round(time.Second).String()},
+				fmt.Sprintf("wait restart cooldown active, remaining=%s\n", remaining.Round(time.Second)))
 			return nil
 		}
 	}
 
+	action := w.nextRecoveryAction(slot)
+
 	// Confirm restart if not auto mode
 	if !w.autoRestart {
-		fmt.Fprintf(w.output, "\nRestart validator %q? [y/N]: ", w.name)
-		reader := bufio.NewReader(w.input)
-		response, err := reader.ReadString('\n')
+		if w.prompt != nil {
+			w.prompt.Lock()
+		}
+		fmt.Fprintf(w.output, "\n"+recoveryPrompts[action]+" [y/N]: ", w.name)
+		response, err := w.input.ReadString('\n')
+		if w.prompt != nil {
+			w.prompt.Unlock()
+		}
 		if err != nil && !errors.Is(err, io.EOF) {
 			return fmt.Errorf("read restart confirmation: %w", err)
 		}
 		response = strings.TrimSpace(strings.ToLower(response))
 		if response != "y" && response != "yes" {
-			fmt.Fprintln(w.output, "restart skipped")
+			w.report(watchEvent{Event: "restart_skipped", Slot: slot, Action: action}, action+" skipped\n")
 			return nil
 		}
 	}
 
 	// Perform restart
-	fmt.Fprintf(w.output, "run  [%s] restarting validator %q\n", time.Now().Format("15:04:05"), w.name)
-
-	restartCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
-	defer cancel()
+	switch action {
+	case recoveryLedgerReset:
+		w.report(watchEvent{Event: "restarting", Slot: slot, Action: action},
+			fmt.Sprintf("run  [%s] resetting ledger of validator %q\n", time.Now().Format("15:04:05"), w.name))
+	case recoveryRedeploy:
+		w.report(watchEvent{Event: "restarting", Slot: slot, Action: action},
+			fmt.Sprintf("run  [%s] redeploying validator %q from its last rendered artifacts\n", time.Now().Format("15:04:05"), w.name))
+	default:
+		w.report(watchEvent{Event: "restarting", Slot: slot, Action: action},
+			fmt.Sprintf("run  [%s] restarting validator %q\n", time.Now().Format("15:04:05"), w.name))
+	}
 
-	if err := w.provider.Restart(restartCtx, w.name); err != nil {
-		fmt.Fprintf(w.output, "fail restart failed: %v\n", err)
-		return fmt.Errorf("restart failed: %w", err)
+	if err := w.runRecovery(ctx, action); err != nil {
+		w.report(watchEvent{Event: "restart_failed", Action: action, Message: err.Error()},
+			fmt.Sprintf("fail %s failed: %v\n", action, err))
+		w.metrics.restarted(w.labels(), false)
+		w.notify(ctx, notify.Event{Type: notify.EventRestartFailed, Action: action, Slot: slot, Message: err.Error()})
+		// A failed restart call is retried; a failed escalation moves on.
+		if w.step > 0 && w.step < len(w.ladder)-1 {
+			w.escalate(slot, action+" failed")
+		}
+		return fmt.Errorf("%s failed: %w", action, err)
 	}
 
 	w.restartCount++
 	w.lastRestartTime = time.Now()
+	w.stepAttempts = append(w.stepAttempts, w.lastRestartTime)
+	w.metrics.restarted(w.labels(), true)
+	w.stuckNotified = false
 
-	fmt.Fprintf(w.output, "done [%s] restart successful restart=%d\n", time.Now().Format("15:04:05"), w.restartCount)
-	fmt.Fprintln(w.output, "wait validator recovery")
-	fmt.Fprintln(w.output, "")
+	w.report(watchEvent{Event: "restart_succeeded", Action: action, Restarts: w.restartCount},
+		fmt.Sprintf("done [%s] %s successful restart=%d\nwait validator recovery\n\n", time.Now().Format("15:04:05"), action, w.restartCount))
+	w.notify(ctx, notify.Event{Type: notify.EventRestartSucceeded, Action: action, Slot: slot})
 
 	return nil
 }
+
+// Recovery ladder steps, mildest first.
+const (
+	recoveryRestart     = "restart"
+	recoveryLedgerReset = "ledger-reset"
+	recoveryRedeploy    = "redeploy"
+)
+
+var recoverySteps = []string{recoveryRestart, recoveryLedgerReset, recoveryRedeploy}
+
+var recoveryPrompts = map[string]string{
+	recoveryRestart:     "Restart validator %q?",
+	recoveryLedgerReset: "Wipe the ledger of %q and restart from genesis?",
+	recoveryRedeploy:    "Redeploy %q from its last rendered artifacts?",
+}
+
+// nextRecoveryAction returns the ladder step to run for a stuck validator.
+// The first step escalates once it has run escalateAfter times inside
+// escalateWindow without the validator recovering; later steps get a single
+// attempt.
+func (w *ValidatorWatcher) nextRecoveryAction(slot uint64) string {
+	if len(w.ladder) == 0 {
+		return recoveryRestart
+	}
+	cutoff := time.Now().Add(-w.escalateWindow)
+	recent := w.stepAttempts[:0]
+	for _, attempt := range w.stepAttempts {
+		if attempt.After(cutoff) {
+			recent = append(recent, attempt)
+		}
+	}
+	w.stepAttempts = recent
+
+	limit := 1
+	if w.step == 0 {
+		limit = w.escalateAfter
+	}
+	if w.step < len(w.ladder)-1 && len(w.stepAttempts) >= limit {
+		w.escalate(slot, fmt.Sprintf("still stuck after %d %s attempt(s) within %s", len(w.stepAttempts), w.ladder[w.step], w.escalateWindow))
+	}
+	return w.ladder[w.step]
+}
+
+func (w *ValidatorWatcher) escalate(slot uint64, reason string) {
+	w.step++
+	w.stepAttempts = nil
+	w.report(watchEvent{Event: "escalating", Slot: slot, Action: w.ladder[w.step], Message: reason},
+		fmt.Sprintf("alert [%s] escalating to %s: %s\n", time.Now().Format("15:04:05"), w.ladder[w.step], reason))
+}
+
+// resetLadder drops back to the first step once the validator progresses
+// again after an escalation.
+func (w *ValidatorWatcher) resetLadder() {
+	if w.step > 0 {
+		w.step = 0
+		w.stepAttempts = nil
+	}
+}
+
+// runRecovery performs one ladder step. Escalations (anything but a plain
+// restart) are recorded as watch-<step> operations in local state.
+func (w *ValidatorWatcher) runRecovery(ctx context.Context, action string) error {
+	if action == recoveryRestart {
+		restartCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+		defer cancel()
+		return w.provider.Restart(restartCtx, w.name)
+	}
+
+	operationID := w.startOperation(action)
+	var err error
+	switch action {
+	case recoveryLedgerReset:
+		resetCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+		defer cancel()
+		// Like `sol-cloud reset`, only a new genesis hash and a healthy RPC
+		// count as a completed wipe.
+		var genesisBefore string
+		_ = rpcCall(resetCtx, w.rpcURL, "getGenesisHash", nil, &genesisBefore)
+		if err = w.provider.ResetLedger(resetCtx, w.name); err == nil {
+			err = waitForFreshLedger(resetCtx, w.rpcURL, genesisBefore)
+		}
+		if err == nil {
+			err = providers.WaitForRPCHealthy(resetCtx, w.rpcURL, 10*time.Minute, resetPollInterval)
+		}
+	case recoveryRedeploy:
+		deployCtx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+		defer cancel()
+		cfg := *w.redeploy
+		_, err = w.provider.Deploy(deployCtx, &cfg)
+	default:
+		err = fmt.Errorf("unknown recovery step %q", action)
+	}
+	if err != nil {
+		w.finishOperation(operationID, action, "failed", err.Error())
+		return err
+	}
+	w.finishOperation(operationID, action, "succeeded", action+" by watch after the validator stalled")
+	return nil
+}
+
+// startOperation records a running watch-<action> operation. State errors are
+// reported but never block recovery.
+func (w *ValidatorWatcher) startOperation(action string) string {
+	w.stateMu.Lock()
+	defer w.stateMu.Unlock()
+	state, err := appconfig.LoadState(w.projectDir)
+	if err == nil {
+		var operation appconfig.OperationRecord
+		operation, err = state.StartOperation("watch-"+action, w.name, w.providerName, action+" started by watch")
+		if err == nil {
+			if err = appconfig.SaveState(w.projectDir, state); err == nil {
+				return operation.ID
+			}
+		}
+	}
+	w.report(watchEvent{Event: "check_error", Message: err.Error()},
+		fmt.Sprintf("warn [%s] record %s operation: %v\n", time.Now().Format("15:04:05"), action, err))
+	return ""
+}
+
+func (w *ValidatorWatcher) finishOperation(id, action, status, message string) {
+	if id == "" {
+		return
+	}
+	w.stateMu.Lock()
+	defer w.stateMu.Unlock()
+	state, err := appconfig.LoadState(w.projectDir)
+	if err == nil {
+		if status == "succeeded" && action == recoveryLedgerReset {
+			state.ClearPrograms(w.name)
+		}
+		if err = state.FinishOperation(id, status, message); err == nil {
+			err = appconfig.SaveState(w.projectDir, state)
+		}
+	}
+	if err != nil {
+		w.report(watchEvent{Event: "check_error", Message: err.Error()},
+			fmt.Sprintf("warn [%s] record %s operation: %v\n", time.Now().Format("15:04:05"), action, err))
+	}
+}
+
+// watchEvent is the structured form of a watcher status line.
+type watchEvent struct {
+	Time     time.Time `json:"time" yaml:"time"`
+	Name     string    `json:"name" yaml:"name"`
+	Event    string    `json:"event" yaml:"event"`
+	Action   string    `json:"action,omitempty" yaml:"action,omitempty"`
+	Slot     uint64    `json:"slot,omitempty" yaml:"slot,omitempty"`
+	Restarts int       `json:"restarts,omitempty" yaml:"restarts,omitempty"`
+	Message  string    `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// report writes event to the structured stream when one is configured and the
+// text line otherwise.
+func (w *ValidatorWatcher) report(event watchEvent, text string) {
+	if w.events == nil {
+		fmt.Fprint(w.output, text)
+		return
+	}
+	event.Time = time.Now().UTC()
+	event.Name = w.name
+	_ = w.events.Write(event)
+}
+
+func (w *ValidatorWatcher) labels() metrics.Labels {
+	return watchLabels(w.name, w.providerName)
+}
+
+// updateProviderState refreshes the provider state metric. It is skipped
+// without --metrics-addr so plain watches do not poll the provider API.
+func (w *ValidatorWatcher) updateProviderState(ctx context.Context) {
+	if w.metrics == nil {
+		return
+	}
+	statusCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	state := "unknown"
+	if status, err := w.provider.Status(statusCtx, w.name); err == nil && status != nil && strings.TrimSpace(status.State) != "" {
+		state = strings.ToLower(strings.TrimSpace(status.State))
+	}
+	w.metrics.providerStateChanged(w.labels(), w.providerState, state)
+	w.providerState = state
+}
+
+// notify sends event to the configured targets. Delivery failures are reported
+// like check errors and never stop the watcher.
+func (w *ValidatorWatcher) notify(ctx context.Context, event notify.Event) {
+	if !w.notifier.Enabled(event.Type) {
+		return
+	}
+	w.alerted = event.Type != notify.EventRecovered
+	event.Deployment = w.name
+	event.Provider = w.providerName
+	event.Restarts = w.restartCount
+	event.MaxRestarts = w.maxRestarts
+
+	// Sent on a fresh context so the max-restarts alert still goes out while
+	// the watcher is shutting down.
+	notifyCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 15*time.Second)
+	defer cancel()
+	if err := w.notifier.Notify(notifyCtx, event); err != nil {
+		w.report(watchEvent{Event: "notify_failed", Message: err.Error()},
+			fmt.Sprintf("warn [%s] notify %s failed: %v\n", time.Now().Format("15:04:05"), event.Type, err))
+	}
+}
+
+// loadWatchNotifyConfig reads watch.notify from the project config and applies
+// --notify-on and --unreachable-checks.
+func loadWatchNotifyConfig(cmd *cobra.Command) (notify.Config, error) {
+	var cfg notify.Config
+	if err := viper.UnmarshalKey("watch.notify", &cfg); err != nil {
+		return cfg, fmt.Errorf("invalid watch.notify in project config: %w", err)
+	}
+	if cmd.Flags().Changed("notify-on") {
+		cfg.Events = map[string]bool{}
+		for _, event := range notify.Events {
+			cfg.Events[event] = false
+		}
+		for _, event := range watchNotifyOn {
+			event = strings.TrimSpace(event)
+			if _, ok := cfg.Events[event]; !ok {
+				return cfg, fmt.Errorf("--notify-on: unknown event %q (expected one of %s)", event, strings.Join(notify.Events, ", "))
+			}
+			cfg.Events[event] = true
+		}
+	}
+	if cmd.Flags().Changed("unreachable-checks") {
+		if watchUnreachable <= 0 {
+			return cfg, errors.New("--unreachable-checks must be > 0")
+		}
+		cfg.UnreachableChecks = watchUnreachable
+	}
+	if cfg.UnreachableChecks == 0 {
+		cfg.UnreachableChecks = notify.DefaultUnreachableChecks
+	}
+	return cfg, nil
+}
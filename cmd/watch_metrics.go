@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/CharlieAIO/sol-cloud/internal/metrics"
+)
+
+// watchMetrics holds the Prometheus series served by `watch --metrics-addr`.
+// Every series is labelled with deployment and provider. Methods are no-ops
+// on a nil receiver so the watcher can call them unconditionally.
+type watchMetrics struct {
+	registry        *metrics.Registry
+	up              *metrics.Gauge
+	slot            *metrics.Gauge
+	slotRate        *metrics.Gauge
+	stuck           *metrics.Gauge
+	stuckDuration   *metrics.Gauge
+	providerState   *metrics.Gauge
+	restarts        *metrics.Counter
+	restartFailures *metrics.Counter
+	rpcLatency      *metrics.Histogram
+}
+
+func newWatchMetrics() *watchMetrics {
+	registry := metrics.NewRegistry()
+	return &watchMetrics{
+		registry:        registry,
+		up:              registry.NewGauge("sol_cloud_up", "Whether the last getSlot call succeeded (1) or failed (0)."),
+		slot:            registry.NewGauge("sol_cloud_slot", "Latest slot reported by the validator."),
+		slotRate:        registry.NewGauge("sol_cloud_slot_rate", "Slots per second across the watcher's recent slot history."),
+		stuck:           registry.NewGauge("sol_cloud_stuck", "Whether the slot has not advanced for longer than the stuck threshold."),
+		stuckDuration:   registry.NewGauge("sol_cloud_stuck_duration_seconds", "How long the validator has been stuck on the same slot, 0 when progressing."),
+		providerState:   registry.NewGauge("sol_cloud_provider_state", "Provider state of the deployment; the current state has value 1."),
+		restarts:        registry.NewCounter("sol_cloud_restarts_total", "Restarts performed by the watcher."),
+		restartFailures: registry.NewCounter("sol_cloud_restart_failures_total", "Restart attempts that failed."),
+		rpcLatency:      registry.NewHistogram("sol_cloud_rpc_latency_seconds", "Latency of successful getSlot calls.", metrics.DefaultLatencyBuckets),
+	}
+}
+
+// serve listens on addr and serves /metrics until ctx is done. Listen errors
+// are returned before the watcher starts.
+func (m *watchMetrics) serve(ctx context.Context, addr string) (string, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", fmt.Errorf("listen on --metrics-addr %s: %w", addr, err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.registry)
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	go func() { _ = server.Serve(listener) }()
+
+	host := listener.Addr().String()
+	if strings.HasPrefix(addr, ":") {
+		host = "localhost" + addr
+	}
+	return "http://" + host + "/metrics", nil
+}
+
+func watchLabels(name, provider string) metrics.Labels {
+	return metrics.Labels{"deployment": name, "provider": provider}
+}
+
+// init exports zero-valued counters so rates work from the first scrape.
+func (m *watchMetrics) init(labels metrics.Labels) {
+	if m == nil {
+		return
+	}
+	m.restarts.Add(labels, 0)
+	m.restartFailures.Add(labels, 0)
+}
+
+func (m *watchMetrics) rpcFailed(labels metrics.Labels) {
+	if m == nil {
+		return
+	}
+	m.up.Set(labels, 0)
+}
+
+func (m *watchMetrics) rpcSucceeded(labels metrics.Labels, slot uint64, latency time.Duration, slotRate float64) {
+	if m == nil {
+		return
+	}
+	m.up.Set(labels, 1)
+	m.slot.Set(labels, float64(slot))
+	m.slotRate.Set(labels, slotRate)
+	m.rpcLatency.Observe(labels, latency.Seconds())
+}
+
+func (m *watchMetrics) stuckState(labels metrics.Labels, stuck bool, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	value := 0.0
+	if stuck {
+		value = 1
+	}
+	m.stuck.Set(labels, value)
+	m.stuckDuration.Set(labels, duration.Seconds())
+}
+
+func (m *watchMetrics) restarted(labels metrics.Labels, ok bool) {
+	if m == nil {
+		return
+	}
+	if ok {
+		m.restarts.Add(labels, 1)
+	} else {
+		m.restartFailures.Add(labels, 1)
+	}
+}
+
+// providerStateChanged moves the state=1 series from previous to state.
+func (m *watchMetrics) providerStateChanged(labels metrics.Labels, previous, state string) {
+	if m == nil {
+		return
+	}
+	if previous != "" && previous != state {
+		m.providerState.Delete(withState(labels, previous))
+	}
+	m.providerState.Set(withState(labels, state), 1)
+}
+
+func withState(labels metrics.Labels, state string) metrics.Labels {
+	copied := metrics.Labels{"state": state}
+	for name, value := range labels {
+		copied[name] = value
+	}
+	return copied
+}
@@ -0,0 +1,184 @@
+package access
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultHeader     = "X-API-Key"
+	DefaultQueryParam = "api_key"
+
+	keyPrefix = "sc_"
+)
+
+// AlwaysAllowedMethods stay reachable under an allowlist so `status`, `watch`,
+// deploy health checks, and airdrop confirmation keep working.
+var AlwaysAllowedMethods = []string{"getHealth", "getSlot", "getVersion", "getRecentPerformanceSamples", "getSignatureStatuses"}
+
+var (
+	headerPattern = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+	// nginx only exposes query parameters made of these characters as $arg_ variables.
+	queryParamPattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+	methodPattern     = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+	keyNamePattern    = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+	keyValuePattern   = regexp.MustCompile(`^[A-Za-z0-9_-]{16,128}$`)
+)
+
+// Config is the project-level `access` section enforced by nginx in front of
+// the validator. Requests need a key once Keys is non-empty, and only
+// AllowedMethods (plus AlwaysAllowedMethods) pass when it is set. /health is
+// always public.
+type Config struct {
+	Header         string   `mapstructure:"header" yaml:"header,omitempty"`
+	QueryParam     string   `mapstructure:"query_param" yaml:"query_param,omitempty"`
+	AllowedMethods []string `mapstructure:"allowed_methods" yaml:"allowed_methods,omitempty"`
+	Keys           []Key    `mapstructure:"keys" yaml:"keys,omitempty"`
+}
+
+// Key is an API key. RateLimit is in requests per second; zero is unlimited.
+type Key struct {
+	Name      string    `mapstructure:"name" yaml:"name"`
+	Key       string    `mapstructure:"key" yaml:"key"`
+	RateLimit int       `mapstructure:"rate_limit" yaml:"rate_limit,omitempty"`
+	CreatedAt time.Time `mapstructure:"created_at" yaml:"created_at,omitempty"`
+}
+
+// ApplyDefaults fills in the header and query parameter names.
+func (c *Config) ApplyDefaults() {
+	if strings.TrimSpace(c.Header) == "" {
+		c.Header = DefaultHeader
+	}
+	if strings.TrimSpace(c.QueryParam) == "" {
+		c.QueryParam = DefaultQueryParam
+	}
+}
+
+// Enabled reports whether requests need an API key.
+func (c Config) Enabled() bool {
+	return len(c.Keys) > 0
+}
+
+// Validate checks that every value can be rendered into nginx config safely.
+func (c Config) Validate() error {
+	if !headerPattern.MatchString(c.Header) {
+		return fmt.Errorf("access.header %q may only contain letters, digits, and dashes", c.Header)
+	}
+	if !queryParamPattern.MatchString(c.QueryParam) {
+		return fmt.Errorf("access.query_param %q may only contain letters, digits, and underscores", c.QueryParam)
+	}
+	for _, method := range c.AllowedMethods {
+		if !methodPattern.MatchString(method) {
+			return fmt.Errorf("access.allowed_methods contains invalid method %q", method)
+		}
+	}
+	names := map[string]struct{}{}
+	values := map[string]struct{}{}
+	for i, key := range c.Keys {
+		if !keyNamePattern.MatchString(key.Name) {
+			return fmt.Errorf("access.keys[%d] name %q must be 1-32 lowercase letters, digits, dashes, or underscores", i, key.Name)
+		}
+		if _, ok := names[key.Name]; ok {
+			return fmt.Errorf("access.keys contains duplicate name %q", key.Name)
+		}
+		names[key.Name] = struct{}{}
+		if !keyValuePattern.MatchString(key.Key) {
+			return fmt.Errorf("access.keys[%d] (%s) key must be 16-128 letters, digits, dashes, or underscores", i, key.Name)
+		}
+		if _, ok := values[key.Key]; ok {
+			return fmt.Errorf("access.keys[%d] (%s) reuses another key's value", i, key.Name)
+		}
+		values[key.Key] = struct{}{}
+		if key.RateLimit < 0 {
+			return fmt.Errorf("access.keys[%d] (%s) rate_limit must be >= 0", i, key.Name)
+		}
+	}
+	return nil
+}
+
+// Methods returns the allowlist including AlwaysAllowedMethods, or nil when
+// every method is allowed.
+func (c Config) Methods() []string {
+	if len(c.AllowedMethods) == 0 {
+		return nil
+	}
+	seen := map[string]struct{}{}
+	var methods []string
+	for _, method := range append(append([]string(nil), c.AllowedMethods...), AlwaysAllowedMethods...) {
+		if _, ok := seen[method]; ok {
+			continue
+		}
+		seen[method] = struct{}{}
+		methods = append(methods, method)
+	}
+	return methods
+}
+
+// AuthorizeURL adds the first key to rpcURL as a query parameter so the CLI
+// can reach a protected deployment. It returns rpcURL unchanged when access is
+// disabled.
+func (c Config) AuthorizeURL(rpcURL string) string {
+	if !c.Enabled() || strings.TrimSpace(rpcURL) == "" {
+		return rpcURL
+	}
+	parsed, err := url.Parse(rpcURL)
+	if err != nil {
+		return rpcURL
+	}
+	query := parsed.Query()
+	query.Set(c.queryParam(), c.Keys[0].Key)
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
+
+func (c Config) queryParam() string {
+	if strings.TrimSpace(c.QueryParam) == "" {
+		return DefaultQueryParam
+	}
+	return c.QueryParam
+}
+
+// Find returns the index of the key called name, or -1.
+func (c Config) Find(name string) int {
+	for i, key := range c.Keys {
+		if key.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// NewKey returns a key with a random value.
+func NewKey(name string, rateLimit int) (Key, error) {
+	if !keyNamePattern.MatchString(name) {
+		return Key{}, fmt.Errorf("key name %q must be 1-32 lowercase letters, digits, dashes, or underscores", name)
+	}
+	if rateLimit < 0 {
+		return Key{}, errors.New("rate limit must be >= 0")
+	}
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return Key{}, fmt.Errorf("generate key: %w", err)
+	}
+	return Key{
+		Name:      name,
+		Key:       keyPrefix + hex.EncodeToString(secret),
+		RateLimit: rateLimit,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}, nil
+}
+
+// Masked returns the key with everything but its prefix and last four
+// characters hidden, for listings.
+func (k Key) Masked() string {
+	if len(k.Key) <= 8 {
+		return strings.Repeat("*", len(k.Key))
+	}
+	return k.Key[:len(keyPrefix)] + "..." + k.Key[len(k.Key)-4:]
+}
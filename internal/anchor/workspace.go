@@ -0,0 +1,261 @@
+package anchor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/CharlieAIO/sol-cloud/internal/solana"
+	"github.com/CharlieAIO/sol-cloud/internal/validator"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// FileName is the Anchor workspace manifest looked up in the project directory.
+const FileName = "Anchor.toml"
+
+// Workspace is the subset of Anchor.toml that maps onto validator config.
+type Workspace struct {
+	Path     string
+	Provider struct {
+		Cluster string `toml:"cluster"`
+		Wallet  string `toml:"wallet"`
+	} `toml:"provider"`
+	// Programs is keyed by cluster, then program name. Values are either the
+	// program ID or a table with an address key.
+	Programs map[string]map[string]any `toml:"programs"`
+	Test     struct {
+		Genesis   []GenesisProgram `toml:"genesis"`
+		Validator struct {
+			URL   string `toml:"url"`
+			Clone []struct {
+				Address string `toml:"address"`
+			} `toml:"clone"`
+			Account []struct {
+				Address  string `toml:"address"`
+				Filename string `toml:"filename"`
+			} `toml:"account"`
+		} `toml:"validator"`
+	} `toml:"test"`
+}
+
+// GenesisProgram is a [[test.genesis]] entry.
+type GenesisProgram struct {
+	Address     string `toml:"address"`
+	Program     string `toml:"program"`
+	Upgradeable bool   `toml:"upgradeable"`
+}
+
+// Import is the validator config derived from a workspace.
+type Import struct {
+	ClonePrograms  []string
+	Accounts       []validator.AccountFixture
+	ProgramDeploys []validator.ProgramDeployConfig
+	CloneRPCURL    string
+}
+
+// Find returns the Anchor.toml path in projectDir when one exists.
+func Find(projectDir string) (string, bool) {
+	path := filepath.Join(projectDir, FileName)
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return "", false
+	}
+	return path, true
+}
+
+// Load parses an Anchor.toml file.
+func Load(path string) (*Workspace, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", FileName, err)
+	}
+	ws := &Workspace{Path: path}
+	if err := toml.Unmarshal(data, ws); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", FileName, err)
+	}
+	return ws, nil
+}
+
+// Import maps the workspace onto validator config. Paths stay relative to the
+// Anchor.toml directory, which is the project directory.
+//
+// Programs in [programs.localnet] load at genesis from target/deploy/<name>.so
+// (run `anchor build` first), and [[test.genesis]] entries load their .so as
+// given. The provider wallet pubkey is used as the upgrade authority for both,
+// matching `anchor test`; when the wallet cannot be read, programs are loaded
+// non-upgradeable.
+func (w *Workspace) Import() (Import, error) {
+	var imp Import
+	authority, err := w.walletPubkey()
+	if err != nil {
+		return Import{}, err
+	}
+
+	localnet := w.Programs["localnet"]
+	names := make([]string, 0, len(localnet))
+	for name := range localnet {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		programID := programAddress(localnet[name])
+		if !validator.IsAddress(programID) {
+			return Import{}, fmt.Errorf("%s: programs.localnet.%s has invalid program ID %q", FileName, name, programID)
+		}
+		imp.ProgramDeploys = append(imp.ProgramDeploys, validator.ProgramDeployConfig{
+			SOPath:               filepath.ToSlash(filepath.Join("target", "deploy", name+".so")),
+			ProgramIDKeypairPath: programID,
+			UpgradeAuthorityPath: authority,
+			Mode:                 validator.ProgramDeployModeGenesis,
+		})
+	}
+
+	for i, program := range w.Test.Genesis {
+		address := strings.TrimSpace(program.Address)
+		if !validator.IsAddress(address) {
+			return Import{}, fmt.Errorf("%s: test.genesis[%d] has invalid address %q", FileName, i, address)
+		}
+		entry := validator.ProgramDeployConfig{
+			SOPath:               strings.TrimSpace(program.Program),
+			ProgramIDKeypairPath: address,
+			Mode:                 validator.ProgramDeployModeGenesis,
+		}
+		if program.Upgradeable {
+			entry.UpgradeAuthorityPath = authority
+		}
+		imp.ProgramDeploys = append(imp.ProgramDeploys, entry)
+	}
+
+	for i, clone := range w.Test.Validator.Clone {
+		address := strings.TrimSpace(clone.Address)
+		if !validator.IsAddress(address) {
+			return Import{}, fmt.Errorf("%s: test.validator.clone[%d] has invalid address %q", FileName, i, address)
+		}
+		imp.ClonePrograms = append(imp.ClonePrograms, address)
+	}
+
+	for i, account := range w.Test.Validator.Account {
+		address := strings.TrimSpace(account.Address)
+		if !validator.IsAddress(address) {
+			return Import{}, fmt.Errorf("%s: test.validator.account[%d] has invalid address %q", FileName, i, address)
+		}
+		imp.Accounts = append(imp.Accounts, validator.AccountFixture{
+			Address: address,
+			Path:    strings.TrimSpace(account.Filename),
+		})
+	}
+
+	imp.CloneRPCURL = strings.TrimSpace(w.Test.Validator.URL)
+	return imp, nil
+}
+
+// ApplyTo merges the import into cfg. Entries for the same program, clone
+// address, or account replace the existing entry in place; new entries are
+// appended. The clone RPC URL only replaces an unset or default value.
+func (imp Import) ApplyTo(cfg *validator.Config) {
+	for _, entry := range imp.ProgramDeploys {
+		replaced := false
+		for i, existing := range cfg.ProgramDeploys {
+			if sameProgram(existing, entry) {
+				cfg.ProgramDeploys[i] = entry
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			cfg.ProgramDeploys = append(cfg.ProgramDeploys, entry)
+		}
+	}
+
+	configured := map[string]struct{}{}
+	for _, list := range [][]string{cfg.ClonePrograms, cfg.CloneAccounts, cfg.CloneUpgradeablePrograms} {
+		for _, address := range list {
+			configured[strings.TrimSpace(address)] = struct{}{}
+		}
+	}
+	for _, entry := range cfg.SourcedClonePrograms {
+		configured[strings.TrimSpace(entry.Address)] = struct{}{}
+	}
+	for _, address := range imp.ClonePrograms {
+		if _, ok := configured[address]; ok {
+			continue
+		}
+		configured[address] = struct{}{}
+		cfg.ClonePrograms = append(cfg.ClonePrograms, address)
+	}
+
+	for _, fixture := range imp.Accounts {
+		replaced := false
+		for i, existing := range cfg.Accounts {
+			if strings.TrimSpace(existing.Address) == fixture.Address {
+				cfg.Accounts[i] = fixture
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			cfg.Accounts = append(cfg.Accounts, fixture)
+		}
+	}
+
+	if imp.CloneRPCURL != "" {
+		current := strings.TrimSpace(cfg.CloneRPCURL)
+		if current == "" || current == validator.DefaultCloneRPCURL {
+			cfg.CloneRPCURL = imp.CloneRPCURL
+		}
+	}
+}
+
+// Summary describes the import for CLI output.
+func (imp Import) Summary() string {
+	return fmt.Sprintf("programs=%d clones=%d accounts=%d", len(imp.ProgramDeploys), len(imp.ClonePrograms), len(imp.Accounts))
+}
+
+func sameProgram(a, b validator.ProgramDeployConfig) bool {
+	if strings.TrimSpace(a.ProgramIDKeypairPath) == strings.TrimSpace(b.ProgramIDKeypairPath) {
+		return true
+	}
+	return filepath.Clean(strings.TrimSpace(a.SOPath)) == filepath.Clean(strings.TrimSpace(b.SOPath))
+}
+
+func programAddress(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case map[string]any:
+		if address, ok := v["address"].(string); ok {
+			return strings.TrimSpace(address)
+		}
+	}
+	return ""
+}
+
+// walletPubkey reads [provider].wallet and returns its public key, or "" when
+// no wallet is configured or the file does not exist on this machine.
+func (w *Workspace) walletPubkey() (string, error) {
+	wallet := strings.TrimSpace(w.Provider.Wallet)
+	if wallet == "" {
+		return "", nil
+	}
+	if rest, ok := strings.CutPrefix(wallet, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", nil
+		}
+		wallet = filepath.Join(home, rest)
+	} else if !filepath.IsAbs(wallet) {
+		wallet = filepath.Join(filepath.Dir(w.Path), wallet)
+	}
+
+	key, err := solana.ReadKeypairPublicKey(wallet)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("read provider wallet %s: %w", w.Provider.Wallet, err)
+	}
+	return key.String(), nil
+}
@@ -0,0 +1,267 @@
+package clonelock
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/CharlieAIO/sol-cloud/internal/solana"
+	"github.com/CharlieAIO/sol-cloud/internal/validator"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ManifestFileName is the clone manifest looked up in the project directory.
+	ManifestFileName = "sol-cloud.clones.yml"
+	// LockFileName is the lockfile written next to the manifest.
+	LockFileName = "sol-cloud.clones.lock"
+	// DefaultSnapshotsDir holds pinned snapshots, relative to the project.
+	DefaultSnapshotsDir = "sol-cloud-clones"
+
+	lockfileVersion = 1
+)
+
+// Entry kinds.
+const (
+	KindProgram     = "program"
+	KindProgramData = "programdata"
+	KindAccount     = "account"
+)
+
+// Manifest lists the programs and accounts `clone sync` pins. Programs also
+// pin their programdata account when they are upgradeable.
+type Manifest struct {
+	Path         string   `yaml:"-"`
+	SourceRPC    string   `yaml:"source_rpc"`
+	SnapshotsDir string   `yaml:"snapshots_dir"`
+	Programs     []string `yaml:"programs"`
+	Accounts     []string `yaml:"accounts"`
+}
+
+// Entry is one pinned account. Path is relative to the project directory and
+// SHA256 is the hex digest of the snapshot data, which for programdata has its
+// deployment slot reset to 0.
+type Entry struct {
+	Address string `json:"address" yaml:"address"`
+	Kind    string `json:"kind" yaml:"kind"`
+	Program string `json:"program,omitempty" yaml:"program,omitempty"`
+	Owner   string `json:"owner" yaml:"owner"`
+	Slot    uint64 `json:"slot" yaml:"slot"`
+	SHA256  string `json:"sha256" yaml:"sha256"`
+	Path    string `json:"path" yaml:"path"`
+}
+
+// Lockfile records the snapshots written by the last `clone sync`.
+type Lockfile struct {
+	Path      string    `json:"-"`
+	Version   int       `json:"version"`
+	SourceRPC string    `json:"source_rpc"`
+	SyncedAt  time.Time `json:"synced_at"`
+	Entries   []Entry   `json:"entries"`
+}
+
+// LoadManifest parses a clone manifest and normalizes its address lists.
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", filepath.Base(path), err)
+	}
+	manifest := &Manifest{Path: path}
+	if err := yaml.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+	}
+	manifest.SourceRPC = strings.TrimSpace(manifest.SourceRPC)
+	manifest.SnapshotsDir = strings.TrimSpace(manifest.SnapshotsDir)
+	if manifest.SnapshotsDir == "" {
+		manifest.SnapshotsDir = DefaultSnapshotsDir
+	}
+
+	seen := map[string]struct{}{}
+	normalize := func(field string, values []string) ([]string, error) {
+		out := make([]string, 0, len(values))
+		for i, raw := range values {
+			address := strings.TrimSpace(raw)
+			if !validator.IsAddress(address) {
+				return nil, fmt.Errorf("%s: %s[%d] has invalid address %q", filepath.Base(path), field, i, address)
+			}
+			if _, ok := seen[address]; ok {
+				continue
+			}
+			seen[address] = struct{}{}
+			out = append(out, address)
+		}
+		return out, nil
+	}
+	if manifest.Programs, err = normalize("programs", manifest.Programs); err != nil {
+		return nil, err
+	}
+	if manifest.Accounts, err = normalize("accounts", manifest.Accounts); err != nil {
+		return nil, err
+	}
+	if len(manifest.Programs) == 0 && len(manifest.Accounts) == 0 {
+		return nil, fmt.Errorf("%s lists no programs or accounts", filepath.Base(path))
+	}
+	return manifest, nil
+}
+
+// FindLockfile returns the lockfile path in projectDir when one exists.
+func FindLockfile(projectDir string) (string, bool) {
+	path := filepath.Join(projectDir, LockFileName)
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return "", false
+	}
+	return path, true
+}
+
+// LoadLockfile parses a lockfile.
+func LoadLockfile(path string) (*Lockfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", filepath.Base(path), err)
+	}
+	lock := &Lockfile{Path: path}
+	if err := json.Unmarshal(data, lock); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+	}
+	if lock.Version != lockfileVersion {
+		return nil, fmt.Errorf("%s has unsupported version %d", filepath.Base(path), lock.Version)
+	}
+	return lock, nil
+}
+
+// NewLockfile returns an empty lockfile for a sync from sourceRPC.
+func NewLockfile(path, sourceRPC string) *Lockfile {
+	return &Lockfile{
+		Path:      path,
+		Version:   lockfileVersion,
+		SourceRPC: sourceRPC,
+		SyncedAt:  time.Now().UTC(),
+		Entries:   []Entry{},
+	}
+}
+
+// Write saves the lockfile to its Path.
+func (l *Lockfile) Write() error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", LockFileName, err)
+	}
+	if err := os.WriteFile(l.Path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", LockFileName, err)
+	}
+	return nil
+}
+
+// DataHash returns the hex SHA-256 digest recorded for account data.
+func DataHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Check confirms every snapshot exists under projectDir and still matches the
+// owner and data hash in the lockfile, so a hand-edited snapshot cannot ship
+// as if it were pinned.
+func (l *Lockfile) Check(projectDir string) error {
+	var problems []string
+	for _, entry := range l.Entries {
+		path := entry.Path
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(projectDir, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", entry.Address, err))
+			continue
+		}
+		address, account, err := solana.ParseAccountSnapshot(data)
+		switch {
+		case err != nil:
+			problems = append(problems, fmt.Sprintf("%s: %v", entry.Address, err))
+		case address != entry.Address:
+			problems = append(problems, fmt.Sprintf("%s: snapshot is for %s", entry.Address, address))
+		case account.Owner != entry.Owner || DataHash(account.Data) != entry.SHA256:
+			problems = append(problems, fmt.Sprintf("%s: snapshot does not match the lockfile", entry.Address))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s is out of date; run `sol-cloud clone sync`:\n  %s", LockFileName, strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// ApplyTo loads every pinned snapshot as an account fixture, replacing
+// fixtures for the same address, and drops pinned addresses from the clone
+// lists so they are not fetched from the cluster at startup.
+func (l *Lockfile) ApplyTo(cfg *validator.Config) {
+	pinned := make(map[string]struct{}, len(l.Entries))
+	for _, entry := range l.Entries {
+		pinned[entry.Address] = struct{}{}
+		fixture := validator.AccountFixture{Address: entry.Address, Path: entry.Path}
+		replaced := false
+		for i, existing := range cfg.Accounts {
+			if strings.TrimSpace(existing.Address) == entry.Address {
+				cfg.Accounts[i] = fixture
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			cfg.Accounts = append(cfg.Accounts, fixture)
+		}
+	}
+	cfg.ClonePrograms = withoutPinned(cfg.ClonePrograms, pinned)
+	cfg.CloneAccounts = withoutPinned(cfg.CloneAccounts, pinned)
+	cfg.CloneUpgradeablePrograms = withoutPinned(cfg.CloneUpgradeablePrograms, pinned)
+	sourced := cfg.SourcedClonePrograms[:0:0]
+	for _, entry := range cfg.SourcedClonePrograms {
+		if _, ok := pinned[strings.TrimSpace(entry.Address)]; !ok {
+			sourced = append(sourced, entry)
+		}
+	}
+	cfg.SourcedClonePrograms = sourced
+}
+
+// Summary describes the lockfile for CLI output.
+func (l *Lockfile) Summary() string {
+	programs := 0
+	for _, entry := range l.Entries {
+		if entry.Kind == KindProgram {
+			programs++
+		}
+	}
+	return fmt.Sprintf("programs=%d accounts=%d synced=%s", programs, len(l.Entries)-programs, l.SyncedAt.Format(time.RFC3339))
+}
+
+// Load reads the lockfile in projectDir and checks its snapshots. It returns
+// nil when the project has no lockfile.
+func Load(projectDir string) (*Lockfile, error) {
+	path, ok := FindLockfile(projectDir)
+	if !ok {
+		return nil, nil
+	}
+	lock, err := LoadLockfile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := lock.Check(projectDir); err != nil {
+		return nil, err
+	}
+	return lock, nil
+}
+
+func withoutPinned(list []string, pinned map[string]struct{}) []string {
+	out := list[:0:0]
+	for _, address := range list {
+		if _, ok := pinned[strings.TrimSpace(address)]; ok {
+			continue
+		}
+		out = append(out, address)
+	}
+	return out
+}
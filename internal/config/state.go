@@ -31,6 +31,24 @@ type DeploymentRecord struct {
 	DashboardURL string    `json:"dashboard_url,omitempty"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
+	// Programs are binaries upgraded onto the running ledger with
+	// `sol-cloud program upgrade`. They are cleared when the ledger is wiped.
+	Programs []ProgramRecord `json:"programs,omitempty"`
+	// HostPort is the localhost port a docker deployment publishes its RPC on.
+	HostPort int `json:"host_port,omitempty"`
+	// VolumeSize (GB) and SkipVolume are the ledger volume settings of the
+	// last deploy, reused when `sol-cloud watch` redeploys.
+	VolumeSize int  `json:"volume_size,omitempty"`
+	SkipVolume bool `json:"skip_volume,omitempty"`
+}
+
+// ProgramRecord is the program binary last upgraded onto a deployment.
+type ProgramRecord struct {
+	ProgramID  string    `json:"program_id"`
+	SHA256     string    `json:"sha256"`
+	Size       int64     `json:"size"`
+	SOPath     string    `json:"so_path"`
+	UpgradedAt time.Time `json:"upgraded_at"`
 }
 
 // OperationRecord tracks command lifecycle state for long-running operations.
@@ -139,6 +157,9 @@ func (s *State) UpsertDeployment(record DeploymentRecord) error {
 	} else if record.CreatedAt.IsZero() {
 		record.CreatedAt = now
 	}
+	if ok && record.Programs == nil {
+		record.Programs = existing.Programs
+	}
 	record.UpdatedAt = now
 
 	s.Deployments[name] = record
@@ -161,6 +182,41 @@ func (s *State) RemoveDeployment(name string) {
 	}
 }
 
+// RecordProgram stores program as the live binary for its program ID on a
+// deployment, replacing any earlier record for that ID.
+func (s *State) RecordProgram(deployment string, program ProgramRecord) error {
+	if s == nil {
+		return errors.New("state is required")
+	}
+	record, ok := s.Deployments[strings.TrimSpace(deployment)]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrDeploymentNotFound, deployment)
+	}
+	programs := make([]ProgramRecord, 0, len(record.Programs)+1)
+	for _, existing := range record.Programs {
+		if existing.ProgramID != program.ProgramID {
+			programs = append(programs, existing)
+		}
+	}
+	record.Programs = append(programs, program)
+	s.Deployments[record.Name] = record
+	return nil
+}
+
+// ClearPrograms forgets the upgraded programs of a deployment after its
+// ledger is wiped.
+func (s *State) ClearPrograms(deployment string) {
+	if s == nil || s.Deployments == nil {
+		return
+	}
+	record, ok := s.Deployments[strings.TrimSpace(deployment)]
+	if !ok {
+		return
+	}
+	record.Programs = nil
+	s.Deployments[record.Name] = record
+}
+
 // StartOperation records a long-running command before remote work begins.
 func (s *State) StartOperation(operationType, deployment, provider, message string) (OperationRecord, error) {
 	if s == nil {
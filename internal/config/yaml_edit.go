@@ -0,0 +1,184 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SetYAMLValue sets the value at path, a list of mapping keys from the
+// document root, and returns the edited YAML. Only the lines of that entry
+// change: comments, key order, and indentation elsewhere are kept. Missing
+// mappings along the path are appended to their parent.
+func SetYAMLValue(content []byte, path []string, value any) ([]byte, error) {
+	if len(path) == 0 {
+		return nil, errors.New("yaml path is required")
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("parse yaml: %w", err)
+	}
+	lines := splitLines(content)
+
+	var parent *yaml.Node
+	if len(doc.Content) > 0 {
+		parent = doc.Content[0]
+		if parent.Kind != yaml.MappingNode || parent.Style&yaml.FlowStyle != 0 {
+			return nil, errors.New("yaml document is not a block mapping")
+		}
+	}
+	indentUnit := detectIndent(parent)
+	parentIndent, parentEnd := 0, len(lines)
+
+	for depth, name := range path {
+		if parent != nil && len(parent.Content) > 0 {
+			parentIndent = parent.Content[0].Column - 1
+		}
+		index := mappingIndex(parent, name)
+		if index < 0 {
+			// Append the rest of the path at the end of the parent block.
+			at := trimTrailing(lines, 0, parentEnd)
+			rendered, err := renderEntry(path[depth:], value, parentIndent, indentUnit)
+			if err != nil {
+				return nil, err
+			}
+			return joinLines(lines[:at], rendered, lines[at:]), nil
+		}
+
+		key, current := parent.Content[index], parent.Content[index+1]
+		start := key.Line - 1
+		end := parentEnd
+		if index+2 < len(parent.Content) {
+			end = parent.Content[index+2].Line - 1
+		}
+		end = trimTrailing(lines, start+1, end)
+
+		last := depth == len(path)-1
+		if last || current.Kind != yaml.MappingNode || current.Style&yaml.FlowStyle != 0 || len(current.Content) == 0 {
+			entryValue := value
+			if !last {
+				// A scalar or flow value in the way is replaced by the nested
+				// mapping; a flow mapping keeps its other keys.
+				nested := map[string]any{}
+				if current.Kind == yaml.MappingNode {
+					if err := current.Decode(&nested); err != nil {
+						return nil, fmt.Errorf("decode %s: %w", strings.Join(path[:depth+1], "."), err)
+					}
+				}
+				setNested(nested, path[depth+1:], value)
+				entryValue = nested
+			}
+			rendered, err := renderEntry([]string{name}, entryValue, parentIndent, indentUnit)
+			if err != nil {
+				return nil, err
+			}
+			return joinLines(lines[:start], rendered, lines[end:]), nil
+		}
+		parent, parentEnd = current, end
+	}
+	return nil, errors.New("unreachable")
+}
+
+func mappingIndex(mapping *yaml.Node, name string) int {
+	if mapping == nil {
+		return -1
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// detectIndent returns the indentation step used by the first nested block
+// mapping, or 2.
+func detectIndent(root *yaml.Node) int {
+	if root == nil {
+		return 2
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		value := root.Content[i+1]
+		if value.Kind == yaml.MappingNode && value.Style&yaml.FlowStyle == 0 && len(value.Content) > 0 {
+			if step := value.Content[0].Column - root.Content[i].Column; step > 0 {
+				return step
+			}
+		}
+	}
+	return 2
+}
+
+// trimTrailing moves end back over blank and comment-only lines, which belong
+// to whatever follows, but not before from.
+func trimTrailing(lines []string, from, end int) int {
+	for end > from {
+		text := strings.TrimSpace(lines[end-1])
+		if text != "" && !strings.HasPrefix(text, "#") {
+			break
+		}
+		end--
+	}
+	return end
+}
+
+func setNested(mapping map[string]any, path []string, value any) {
+	for _, name := range path[:len(path)-1] {
+		next, ok := mapping[name].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			mapping[name] = next
+		}
+		mapping = next
+	}
+	mapping[path[len(path)-1]] = value
+}
+
+// renderEntry renders {path[0]: {path[1]: ... value}} as block YAML lines
+// indented by indent spaces.
+func renderEntry(path []string, value any, indent, indentUnit int) ([]string, error) {
+	nested := map[string]any{}
+	setNested(nested, path, value)
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(indentUnit)
+	if err := encoder.Encode(nested); err != nil {
+		return nil, fmt.Errorf("encode %s: %w", strings.Join(path, "."), err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("encode %s: %w", strings.Join(path, "."), err)
+	}
+	prefix := strings.Repeat(" ", indent)
+	rendered := splitLines(buf.Bytes())
+	for i, line := range rendered {
+		rendered[i] = prefix + line
+	}
+	return rendered, nil
+}
+
+// splitLines splits content into lines that keep their newline.
+func splitLines(content []byte) []string {
+	var lines []string
+	for len(content) > 0 {
+		i := bytes.IndexByte(content, '\n')
+		if i < 0 {
+			lines = append(lines, string(content)+"\n")
+			break
+		}
+		lines = append(lines, string(content[:i+1]))
+		content = content[i+1:]
+	}
+	return lines
+}
+
+func joinLines(parts ...[]string) []byte {
+	var buf bytes.Buffer
+	for _, part := range parts {
+		for _, line := range part {
+			buf.WriteString(line)
+		}
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestSetYAMLValue(t *testing.T) {
+	type key struct {
+		Name string `yaml:"name"`
+		Key  string `yaml:"key"`
+	}
+	keys := []key{{Name: "ci", Key: "sk_1"}}
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name: "replaces existing keys only",
+			input: `# project config
+provider: docker
+app_name: demo   # container name
+
+access:
+    header: X-Api-Key
+    # keys below are managed by sol-cloud keys
+    keys:
+        - name: old
+          key: sk_0
+    methods: [getSlot]
+
+# validator tuning
+validator:
+    slots_per_epoch: 32
+`,
+			want: `# project config
+provider: docker
+app_name: demo   # container name
+
+access:
+    header: X-Api-Key
+    # keys below are managed by sol-cloud keys
+    keys:
+        - name: ci
+          key: sk_1
+    methods: [getSlot]
+
+# validator tuning
+validator:
+    slots_per_epoch: 32
+`,
+		},
+		{
+			name: "adds keys to the end of access",
+			input: `provider: fly
+access:
+  header: X-Api-Key
+
+# trailing comment
+region: ord
+`,
+			want: `provider: fly
+access:
+  header: X-Api-Key
+  keys:
+    - name: ci
+      key: sk_1
+
+# trailing comment
+region: ord
+`,
+		},
+		{
+			name: "appends access when missing",
+			input: `provider: fly
+region: ord # closest
+`,
+			want: `provider: fly
+region: ord # closest
+access:
+  keys:
+    - name: ci
+      key: sk_1
+`,
+		},
+		{
+			name: "replaces an empty access entry",
+			input: `access:
+provider: fly
+`,
+			want: `access:
+  keys:
+    - name: ci
+      key: sk_1
+provider: fly
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := SetYAMLValue([]byte(test.input), []string{"access", "keys"}, keys)
+			if err != nil {
+				t.Fatalf("SetYAMLValue: %v", err)
+			}
+			if string(got) != test.want {
+				t.Fatalf("got:\n%s\nwant:\n%s", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultLatencyBuckets are histogram upper bounds in seconds for RPC calls.
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Labels identify one series within a metric family.
+type Labels map[string]string
+
+// Registry holds metric families and renders them in the Prometheus text
+// exposition format. It is safe for concurrent use.
+type Registry struct {
+	mu       sync.Mutex
+	families []*family
+}
+
+type family struct {
+	name    string
+	help    string
+	kind    string
+	buckets []float64
+	series  map[string]*series
+}
+
+type series struct {
+	labels Labels
+	value  float64
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Gauge is a metric that can go up and down.
+type Gauge struct {
+	registry *Registry
+	family   *family
+}
+
+// Counter is a metric that only goes up.
+type Counter struct {
+	registry *Registry
+	family   *family
+}
+
+// Histogram counts observations into cumulative buckets.
+type Histogram struct {
+	registry *Registry
+	family   *family
+}
+
+// NewGauge registers a gauge family.
+func (r *Registry) NewGauge(name, help string) *Gauge {
+	return &Gauge{registry: r, family: r.register(name, help, "gauge", nil)}
+}
+
+// NewCounter registers a counter family.
+func (r *Registry) NewCounter(name, help string) *Counter {
+	return &Counter{registry: r, family: r.register(name, help, "counter", nil)}
+}
+
+// NewHistogram registers a histogram family with the given bucket upper
+// bounds; +Inf is implied.
+func (r *Registry) NewHistogram(name, help string, buckets []float64) *Histogram {
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	return &Histogram{registry: r, family: r.register(name, help, "histogram", sorted)}
+}
+
+func (r *Registry) register(name, help, kind string, buckets []float64) *family {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f := &family{name: name, help: help, kind: kind, buckets: buckets, series: map[string]*series{}}
+	r.families = append(r.families, f)
+	return f
+}
+
+// Set sets the gauge for labels.
+func (g *Gauge) Set(labels Labels, value float64) {
+	g.registry.mu.Lock()
+	defer g.registry.mu.Unlock()
+	g.family.get(labels).value = value
+}
+
+// Delete drops the series for labels, e.g. a state label that no longer
+// applies.
+func (g *Gauge) Delete(labels Labels) {
+	g.registry.mu.Lock()
+	defer g.registry.mu.Unlock()
+	delete(g.family.series, labelKey(labels))
+}
+
+// Add increases the counter for labels. Negative values are ignored.
+func (c *Counter) Add(labels Labels, value float64) {
+	c.registry.mu.Lock()
+	defer c.registry.mu.Unlock()
+	s := c.family.get(labels)
+	if value > 0 {
+		s.value += value
+	}
+}
+
+// Observe records value in the histogram for labels.
+func (h *Histogram) Observe(labels Labels, value float64) {
+	h.registry.mu.Lock()
+	defer h.registry.mu.Unlock()
+	s := h.family.get(labels)
+	for i, bound := range h.family.buckets {
+		if value <= bound {
+			s.counts[i]++
+		}
+	}
+	s.sum += value
+	s.count++
+}
+
+func (f *family) get(labels Labels) *series {
+	key := labelKey(labels)
+	s, ok := f.series[key]
+	if !ok {
+		copied := make(Labels, len(labels))
+		for name, value := range labels {
+			copied[name] = value
+		}
+		s = &series{labels: copied}
+		if f.kind == "histogram" {
+			s.counts = make([]uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+// WriteText renders every family in the Prometheus text format. Families
+// without series are skipped.
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := bufio.NewWriter(w)
+	for _, f := range r.families {
+		if len(f.series) == 0 {
+			continue
+		}
+		fmt.Fprintf(out, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+		fmt.Fprintf(out, "# TYPE %s %s\n", f.name, f.kind)
+		keys := make([]string, 0, len(f.series))
+		for key := range f.series {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			s := f.series[key]
+			if f.kind != "histogram" {
+				fmt.Fprintf(out, "%s%s %s\n", f.name, key, formatFloat(s.value))
+				continue
+			}
+			for i, bound := range f.buckets {
+				fmt.Fprintf(out, "%s_bucket%s %d\n", f.name, withLabel(s.labels, "le", formatFloat(bound)), s.counts[i])
+			}
+			fmt.Fprintf(out, "%s_bucket%s %d\n", f.name, withLabel(s.labels, "le", "+Inf"), s.count)
+			fmt.Fprintf(out, "%s_sum%s %s\n", f.name, key, formatFloat(s.sum))
+			fmt.Fprintf(out, "%s_count%s %d\n", f.name, key, s.count)
+		}
+	}
+	return out.Flush()
+}
+
+// ServeHTTP serves the registry for Prometheus scrapes.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = r.WriteText(w)
+}
+
+// labelKey renders labels sorted by name, e.g. {a="1",b="2"}. It doubles as
+// the series key.
+func labelKey(labels Labels) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s=%q", name, escapeLabel(labels[name])))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// withLabel renders labels plus one more label placed last, as Prometheus
+// expects for le.
+func withLabel(labels Labels, name, value string) string {
+	key := labelKey(labels)
+	extra := fmt.Sprintf("%s=%q", name, value)
+	if key == "" {
+		return "{" + extra + "}"
+	}
+	return key[:len(key)-1] + "," + extra + "}"
+}
+
+// escapeLabel replaces non-printable runes with spaces so %q only produces
+// the \\, \", and \n escapes Prometheus accepts.
+func escapeLabel(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\\' || r == '"' || strconv.IsPrint(r) {
+			return r
+		}
+		return ' '
+	}, value)
+}
+
+func escapeHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
+
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
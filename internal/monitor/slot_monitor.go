@@ -125,6 +125,25 @@ func (h *SlotHistory) HasProgressed() bool {
 	return h.entries[len(h.entries)-1].Slot != h.entries[len(h.entries)-2].Slot
 }
 
+// Rate returns the slot progression in slots per second across the recorded
+// history, or 0 with fewer than two observations or when the slot went
+// backwards (e.g. after a ledger reset).
+func (h *SlotHistory) Rate() float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.entries) < 2 {
+		return 0
+	}
+	first := h.entries[0]
+	last := h.entries[len(h.entries)-1]
+	elapsed := last.Timestamp.Sub(first.Timestamp).Seconds()
+	if elapsed <= 0 || last.Slot < first.Slot {
+		return 0
+	}
+	return float64(last.Slot-first.Slot) / elapsed
+}
+
 // String returns a human-readable representation of the stuck info.
 func (s *StuckInfo) String() string {
 	return fmt.Sprintf("Stuck on slot %d for %s (observed %d times, first at %s)",
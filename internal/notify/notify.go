@@ -0,0 +1,419 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Watch events that can be sent to notifiers.
+const (
+	EventStuck            = "stuck"
+	EventRestartSucceeded = "restart_succeeded"
+	EventRestartFailed    = "restart_failed"
+	EventMaxRestarts      = "max_restarts"
+	EventRPCUnreachable   = "rpc_unreachable"
+	EventRecovered        = "recovered"
+)
+
+// Events lists every event in the order they are documented.
+var Events = []string{EventStuck, EventRestartSucceeded, EventRestartFailed, EventMaxRestarts, EventRPCUnreachable, EventRecovered}
+
+// Notifier types.
+const (
+	TypeWebhook   = "webhook"
+	TypeSlack     = "slack"
+	TypeDiscord   = "discord"
+	TypePagerDuty = "pagerduty"
+)
+
+const (
+	DefaultPagerDutyURL      = "https://events.pagerduty.com/v2/enqueue"
+	DefaultUnreachableChecks = 3
+
+	requestTimeout = 10 * time.Second
+)
+
+var defaultTemplates = map[string]string{
+	EventStuck:            `sol-cloud: {{.Deployment}} is stuck: {{.Message}}`,
+	EventRestartSucceeded: `sol-cloud: {{.Action}} of {{.Deployment}} succeeded (recovery {{.Restarts}})`,
+	EventRestartFailed:    `sol-cloud: {{.Action}} of {{.Deployment}} failed: {{.Message}}`,
+	EventMaxRestarts:      `sol-cloud: {{.Deployment}} reached max restarts ({{.Restarts}}); watcher stopped`,
+	EventRPCUnreachable:   `sol-cloud: {{.Deployment}} RPC unreachable for {{.Checks}} checks: {{.Message}}`,
+	EventRecovered:        `sol-cloud: {{.Deployment}} recovered at slot {{.Slot}}`,
+}
+
+// Config is the `watch.notify` section of the project config. Events turns
+// single events off (every event is on by default), Templates overrides the
+// message text per event, and UnreachableChecks is how many failed checks in a
+// row raise rpc_unreachable.
+type Config struct {
+	UnreachableChecks int               `mapstructure:"unreachable_checks" yaml:"unreachable_checks,omitempty"`
+	Events            map[string]bool   `mapstructure:"events" yaml:"events,omitempty"`
+	Templates         map[string]string `mapstructure:"templates" yaml:"templates,omitempty"`
+	Targets           []Target          `mapstructure:"targets" yaml:"targets,omitempty"`
+}
+
+// Target is one notifier. URL is the webhook URL; PagerDuty targets need a
+// RoutingKey and default to the Events v2 endpoint. Events, when set, limits
+// the target to those events.
+type Target struct {
+	Type       string   `mapstructure:"type" yaml:"type"`
+	URL        string   `mapstructure:"url" yaml:"url,omitempty"`
+	RoutingKey string   `mapstructure:"routing_key" yaml:"routing_key,omitempty"`
+	Events     []string `mapstructure:"events" yaml:"events,omitempty"`
+}
+
+// Event describes something the watcher saw. It is also the data passed to
+// message templates. Action is the recovery step (restart, ledger-reset, or
+// redeploy) for restart events.
+type Event struct {
+	Type        string
+	Action      string
+	Deployment  string
+	Provider    string
+	Time        time.Time
+	Slot        uint64
+	Restarts    int
+	MaxRestarts int
+	Checks      int
+	Message     string
+}
+
+// sampleEvent has every field set so Validate can execute custom templates.
+var sampleEvent = Event{
+	Type:        EventStuck,
+	Action:      "restart",
+	Deployment:  "validator",
+	Provider:    "fly",
+	Time:        time.Unix(0, 0).UTC(),
+	Slot:        1,
+	Restarts:    1,
+	MaxRestarts: 1,
+	Checks:      1,
+	Message:     "sample",
+}
+
+// Notifier delivers an event with its rendered message text.
+type Notifier interface {
+	Notify(ctx context.Context, event Event, text string) error
+}
+
+// Validate checks event names, templates, and targets.
+func (c Config) Validate() error {
+	if c.UnreachableChecks < 0 {
+		return errors.New("watch.notify.unreachable_checks must be >= 0")
+	}
+	for event := range c.Events {
+		if !knownEvent(event) {
+			return fmt.Errorf("watch.notify.events has unknown event %q (expected one of %s)", event, strings.Join(Events, ", "))
+		}
+	}
+	for event, text := range c.Templates {
+		if !knownEvent(event) {
+			return fmt.Errorf("watch.notify.templates has unknown event %q (expected one of %s)", event, strings.Join(Events, ", "))
+		}
+		tmpl, err := parseTemplate(event, text)
+		if err != nil {
+			return fmt.Errorf("watch.notify.templates.%s: %w", event, err)
+		}
+		// Unknown fields only fail at execution, so catch them here rather
+		// than on every alert.
+		if err := tmpl.Execute(io.Discard, sampleEvent); err != nil {
+			return fmt.Errorf("watch.notify.templates.%s: %w", event, err)
+		}
+	}
+	for i, target := range c.Targets {
+		if err := target.validate(); err != nil {
+			return fmt.Errorf("watch.notify.targets[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (t Target) validate() error {
+	switch strings.ToLower(strings.TrimSpace(t.Type)) {
+	case TypeWebhook, TypeSlack, TypeDiscord:
+		if strings.TrimSpace(t.URL) == "" {
+			return fmt.Errorf("%s target needs a url", t.Type)
+		}
+	case TypePagerDuty:
+		if strings.TrimSpace(t.RoutingKey) == "" {
+			return errors.New("pagerduty target needs a routing_key")
+		}
+	default:
+		return fmt.Errorf("unknown type %q (expected webhook, slack, discord, or pagerduty)", t.Type)
+	}
+	if strings.TrimSpace(t.URL) != "" {
+		parsed, err := url.Parse(strings.TrimSpace(t.URL))
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("url %q must be an http(s) URL", t.URL)
+		}
+	}
+	for _, event := range t.Events {
+		if !knownEvent(event) {
+			return fmt.Errorf("unknown event %q (expected one of %s)", event, strings.Join(Events, ", "))
+		}
+	}
+	return nil
+}
+
+// Dispatcher renders events and sends them to every target subscribed to them.
+type Dispatcher struct {
+	targets   []dispatchTarget
+	enabled   map[string]bool
+	templates map[string]*template.Template
+}
+
+type dispatchTarget struct {
+	name     string
+	notifier Notifier
+	events   map[string]bool
+}
+
+// New validates cfg and builds a Dispatcher. It returns nil when cfg has no
+// targets.
+func New(cfg Config) (*Dispatcher, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if len(cfg.Targets) == 0 {
+		return nil, nil
+	}
+
+	d := &Dispatcher{
+		enabled:   map[string]bool{},
+		templates: map[string]*template.Template{},
+	}
+	client := &http.Client{Timeout: requestTimeout}
+	for _, event := range Events {
+		enabled, ok := cfg.Events[event]
+		d.enabled[event] = !ok || enabled
+		text := defaultTemplates[event]
+		if custom := strings.TrimSpace(cfg.Templates[event]); custom != "" {
+			text = custom
+		}
+		tmpl, err := parseTemplate(event, text)
+		if err != nil {
+			return nil, fmt.Errorf("watch.notify.templates.%s: %w", event, err)
+		}
+		d.templates[event] = tmpl
+	}
+	for _, target := range cfg.Targets {
+		kind := strings.ToLower(strings.TrimSpace(target.Type))
+		entry := dispatchTarget{name: kind, notifier: newNotifier(kind, target, client)}
+		if len(target.Events) > 0 {
+			entry.events = map[string]bool{}
+			for _, event := range target.Events {
+				entry.events[event] = true
+			}
+		}
+		d.targets = append(d.targets, entry)
+	}
+	return d, nil
+}
+
+// Enabled reports whether event is sent anywhere.
+func (d *Dispatcher) Enabled(event string) bool {
+	if d == nil || !d.enabled[event] {
+		return false
+	}
+	for _, target := range d.targets {
+		if target.events == nil || target.events[event] {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary describes the targets and enabled events for the watch header.
+func (d *Dispatcher) Summary() string {
+	if d == nil {
+		return ""
+	}
+	names := make([]string, 0, len(d.targets))
+	for _, target := range d.targets {
+		names = append(names, target.name)
+	}
+	var events []string
+	for _, event := range Events {
+		if d.Enabled(event) {
+			events = append(events, event)
+		}
+	}
+	if len(events) == 0 {
+		return strings.Join(names, ", ") + " (no events enabled)"
+	}
+	return fmt.Sprintf("%s (%s)", strings.Join(names, ", "), strings.Join(events, ", "))
+}
+
+// Notify sends event to every subscribed target. Target errors are joined so
+// one failing webhook does not hide another.
+func (d *Dispatcher) Notify(ctx context.Context, event Event) error {
+	if !d.Enabled(event.Type) {
+		return nil
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	var text strings.Builder
+	if err := d.templates[event.Type].Execute(&text, event); err != nil {
+		return fmt.Errorf("render %s message: %w", event.Type, err)
+	}
+
+	var errs []error
+	for _, target := range d.targets {
+		if target.events != nil && !target.events[event.Type] {
+			continue
+		}
+		if err := target.notifier.Notify(ctx, event, text.String()); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", target.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func knownEvent(event string) bool {
+	for _, known := range Events {
+		if event == known {
+			return true
+		}
+	}
+	return false
+}
+
+func parseTemplate(event, text string) (*template.Template, error) {
+	return template.New(event).Option("missingkey=error").Parse(text)
+}
+
+func newNotifier(kind string, target Target, client *http.Client) Notifier {
+	endpoint := strings.TrimSpace(target.URL)
+	switch kind {
+	case TypeSlack:
+		return &httpNotifier{url: endpoint, client: client, payload: func(_ Event, text string) any {
+			return map[string]string{"text": text}
+		}}
+	case TypeDiscord:
+		return &httpNotifier{url: endpoint, client: client, payload: func(_ Event, text string) any {
+			return map[string]string{"content": text}
+		}}
+	case TypePagerDuty:
+		if endpoint == "" {
+			endpoint = DefaultPagerDutyURL
+		}
+		routingKey := strings.TrimSpace(target.RoutingKey)
+		return &httpNotifier{url: endpoint, client: client, payload: func(event Event, text string) any {
+			return pagerDutyPayload(routingKey, event, text)
+		}}
+	default:
+		return &httpNotifier{url: endpoint, client: client, payload: webhookPayload}
+	}
+}
+
+// httpNotifier POSTs a JSON payload built from the event.
+type httpNotifier struct {
+	url     string
+	client  *http.Client
+	payload func(Event, string) any
+}
+
+func (n *httpNotifier) Notify(ctx context.Context, event Event, text string) error {
+	body, err := json.Marshal(n.payload(event, text))
+	if err != nil {
+		return fmt.Errorf("encode payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		// url.Error repeats the full URL, secret path included.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("post to %s: %w", redactURL(n.url), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("post to %s: status %d: %s", redactURL(n.url), resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// webhookPayload is the generic JSON body.
+func webhookPayload(event Event, text string) any {
+	return struct {
+		Event       string    `json:"event"`
+		Action      string    `json:"action,omitempty"`
+		Deployment  string    `json:"deployment"`
+		Provider    string    `json:"provider,omitempty"`
+		Time        time.Time `json:"time"`
+		Slot        uint64    `json:"slot,omitempty"`
+		Restarts    int       `json:"restarts,omitempty"`
+		MaxRestarts int       `json:"max_restarts,omitempty"`
+		Checks      int       `json:"checks,omitempty"`
+		Message     string    `json:"message,omitempty"`
+		Text        string    `json:"text"`
+	}{event.Type, event.Action, event.Deployment, event.Provider, event.Time, event.Slot, event.Restarts, event.MaxRestarts, event.Checks, event.Message, text}
+}
+
+// pagerDutyPayload builds an Events v2 body. Every event for a deployment
+// shares one dedup key, so recovered resolves the open incident. A restart
+// only updates it, since the validator may still not produce slots.
+func pagerDutyPayload(routingKey string, event Event, text string) any {
+	body := map[string]any{
+		"routing_key":  routingKey,
+		"event_action": "trigger",
+		"dedup_key":    "sol-cloud/" + event.Deployment,
+	}
+	if event.Type == EventRecovered {
+		body["event_action"] = "resolve"
+		return body
+	}
+	severity := "error"
+	switch event.Type {
+	case EventRestartFailed, EventMaxRestarts:
+		severity = "critical"
+	case EventRPCUnreachable:
+		severity = "warning"
+	case EventRestartSucceeded:
+		severity = "info"
+	}
+	body["payload"] = map[string]any{
+		"summary":   text,
+		"source":    event.Deployment,
+		"severity":  severity,
+		"timestamp": event.Time.UTC().Format(time.RFC3339),
+		"component": event.Provider,
+		"class":     event.Type,
+		"custom_details": map[string]any{
+			"slot":     event.Slot,
+			"action":   event.Action,
+			"restarts": event.Restarts,
+			"message":  event.Message,
+		},
+	}
+	return body
+}
+
+// redactURL drops the path and query, which carry the secret for Slack and
+// Discord webhooks.
+func redactURL(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "webhook"
+	}
+	return parsed.Scheme + "://" + parsed.Host
+}
@@ -0,0 +1,218 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder is a local HTTP stand-in that keeps every JSON body by path.
+type recorder struct {
+	mu     sync.Mutex
+	bodies map[string][]map[string]any
+}
+
+func newRecorder(t *testing.T) (*recorder, *httptest.Server) {
+	t.Helper()
+	rec := &recorder{bodies: map[string][]map[string]any{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/fail/") {
+			http.Error(w, "nope", http.StatusInternalServerError)
+			return
+		}
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		rec.mu.Lock()
+		rec.bodies[r.URL.Path] = append(rec.bodies[r.URL.Path], body)
+		rec.mu.Unlock()
+	}))
+	t.Cleanup(server.Close)
+	return rec, server
+}
+
+func (r *recorder) get(path string) []map[string]any {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.bodies[path]
+}
+
+func TestDispatcherPayloads(t *testing.T) {
+	rec, server := newRecorder(t)
+	dispatcher, err := New(Config{
+		Templates: map[string]string{EventStuck: "{{.Deployment}} stuck at {{.Slot}}"},
+		Targets: []Target{
+			{Type: TypeWebhook, URL: server.URL + "/hook"},
+			{Type: TypeSlack, URL: server.URL + "/slack"},
+			{Type: TypeDiscord, URL: server.URL + "/discord", Events: []string{EventMaxRestarts}},
+			{Type: TypePagerDuty, URL: server.URL + "/pd", RoutingKey: "rk"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	stuck := Event{Type: EventStuck, Deployment: "qa", Provider: "fly", Time: at, Slot: 7, Message: "no progress"}
+	if err := dispatcher.Notify(context.Background(), stuck); err != nil {
+		t.Fatalf("Notify stuck: %v", err)
+	}
+	restarted := Event{Type: EventRestartSucceeded, Action: "restart", Deployment: "qa", Provider: "fly", Time: at, Restarts: 1}
+	if err := dispatcher.Notify(context.Background(), restarted); err != nil {
+		t.Fatalf("Notify restart_succeeded: %v", err)
+	}
+	recovered := Event{Type: EventRecovered, Deployment: "qa", Provider: "fly", Time: at, Slot: 9, Restarts: 1}
+	if err := dispatcher.Notify(context.Background(), recovered); err != nil {
+		t.Fatalf("Notify recovered: %v", err)
+	}
+
+	hooks := rec.get("/hook")
+	if len(hooks) != 3 {
+		t.Fatalf("webhook got %d bodies, want 3", len(hooks))
+	}
+	for field, want := range map[string]any{
+		"event":      "stuck",
+		"deployment": "qa",
+		"provider":   "fly",
+		"time":       "2026-01-02T03:04:05Z",
+		"slot":       float64(7),
+		"message":    "no progress",
+		"text":       "qa stuck at 7",
+	} {
+		if hooks[0][field] != want {
+			t.Errorf("webhook %s = %v, want %v", field, hooks[0][field], want)
+		}
+	}
+	if hooks[1]["action"] != "restart" || hooks[1]["text"] != "sol-cloud: restart of qa succeeded (recovery 1)" {
+		t.Errorf("webhook restart body = %v", hooks[1])
+	}
+	if hooks[2]["event"] != "recovered" || hooks[2]["text"] != "sol-cloud: qa recovered at slot 9" {
+		t.Errorf("webhook recovered body = %v", hooks[2])
+	}
+
+	slack := rec.get("/slack")
+	if len(slack) != 3 || len(slack[0]) != 1 || slack[0]["text"] != "qa stuck at 7" {
+		t.Errorf("slack bodies = %v, want {text} only", slack)
+	}
+
+	// Discord only subscribes to max_restarts.
+	if got := rec.get("/discord"); len(got) != 0 {
+		t.Errorf("discord got %v, want nothing before max_restarts", got)
+	}
+	if err := dispatcher.Notify(context.Background(), Event{Type: EventMaxRestarts, Deployment: "qa", Restarts: 3}); err != nil {
+		t.Fatalf("Notify max_restarts: %v", err)
+	}
+	discord := rec.get("/discord")
+	if len(discord) != 1 || discord[0]["content"] != "sol-cloud: qa reached max restarts (3); watcher stopped" {
+		t.Errorf("discord bodies = %v", discord)
+	}
+
+	pd := rec.get("/pd")
+	if len(pd) != 4 {
+		t.Fatalf("pagerduty got %d bodies, want 4", len(pd))
+	}
+	trigger := pd[0]
+	if trigger["routing_key"] != "rk" || trigger["event_action"] != "trigger" || trigger["dedup_key"] != "sol-cloud/qa" {
+		t.Errorf("pagerduty trigger = %v", trigger)
+	}
+	payload, _ := trigger["payload"].(map[string]any)
+	if payload["summary"] != "qa stuck at 7" || payload["severity"] != "error" || payload["class"] != "stuck" || payload["component"] != "fly" {
+		t.Errorf("pagerduty trigger payload = %v", payload)
+	}
+	// A restart keeps the incident open; only recovered resolves it.
+	restart := pd[1]
+	if restart["event_action"] != "trigger" || restart["dedup_key"] != "sol-cloud/qa" {
+		t.Errorf("pagerduty restart = %v", restart)
+	}
+	if severity := restart["payload"].(map[string]any)["severity"]; severity != "info" {
+		t.Errorf("pagerduty restart_succeeded severity = %v, want info", severity)
+	}
+	resolve := pd[2]
+	if resolve["event_action"] != "resolve" || resolve["dedup_key"] != "sol-cloud/qa" || resolve["payload"] != nil {
+		t.Errorf("pagerduty resolve = %v", resolve)
+	}
+	if severity := pd[3]["payload"].(map[string]any)["severity"]; severity != "critical" {
+		t.Errorf("pagerduty max_restarts severity = %v, want critical", severity)
+	}
+}
+
+func TestDispatcherEventSwitches(t *testing.T) {
+	rec, server := newRecorder(t)
+	dispatcher, err := New(Config{
+		Events:  map[string]bool{EventRestartSucceeded: false},
+		Targets: []Target{{Type: TypeSlack, URL: server.URL + "/slack"}},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if dispatcher.Enabled(EventRestartSucceeded) || !dispatcher.Enabled(EventStuck) {
+		t.Fatalf("Enabled does not follow watch.notify.events")
+	}
+	if err := dispatcher.Notify(context.Background(), Event{Type: EventRestartSucceeded, Deployment: "qa"}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if got := rec.get("/slack"); len(got) != 0 {
+		t.Errorf("disabled event was sent: %v", got)
+	}
+}
+
+func TestDispatcherRedactsURLs(t *testing.T) {
+	_, server := newRecorder(t)
+	dispatcher, err := New(Config{Targets: []Target{
+		{Type: TypeSlack, URL: server.URL + "/fail/T000/secret-token"},
+		{Type: TypeDiscord, URL: "http://127.0.0.1:1/api/webhooks/secret-token"},
+	}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	err = dispatcher.Notify(context.Background(), Event{Type: EventStuck, Deployment: "qa"})
+	if err == nil {
+		t.Fatal("Notify succeeded, want errors from both targets")
+	}
+	message := err.Error()
+	if strings.Contains(message, "secret-token") || strings.Contains(message, "/fail/") {
+		t.Errorf("error leaks the webhook path: %s", message)
+	}
+	for _, want := range []string{"slack: post to " + server.URL + ": status 500: nope", "discord: post to http://127.0.0.1:1:"} {
+		if !strings.Contains(message, want) {
+			t.Errorf("error %q does not contain %q", message, want)
+		}
+	}
+}
+
+func TestValidateExecutesTemplates(t *testing.T) {
+	for name, text := range map[string]string{
+		"unknown field": "{{.Slott}}",
+		"syntax":        "{{.Slot",
+	} {
+		cfg := Config{Templates: map[string]string{EventStuck: text}}
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "watch.notify.templates.stuck") {
+			t.Errorf("%s: Validate error = %v", name, err)
+		}
+	}
+	cfg := Config{Templates: map[string]string{EventStuck: `{{.Deployment}} {{.Time.Format "15:04"}} {{.Action}} {{.Checks}}`}}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate rejected a valid template: %v", err)
+	}
+}
+
+func TestNewWithoutTargets(t *testing.T) {
+	dispatcher, err := New(Config{})
+	if err != nil || dispatcher != nil {
+		t.Fatalf("New(empty) = %v, %v; want nil, nil", dispatcher, err)
+	}
+	if dispatcher.Enabled(EventStuck) || dispatcher.Notify(context.Background(), Event{Type: EventStuck}) != nil {
+		t.Fatal("nil dispatcher should be a no-op")
+	}
+}
@@ -0,0 +1,294 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	defaultDockerSocket      = "/var/run/docker.sock"
+	defaultDockerHostPort    = 8080
+	defaultDockerHTTPTimeout = 30 * time.Second
+	dockerImagePrefix        = "sol-cloud/"
+)
+
+// dockerTemplateData is the template data passed to provider-agnostic templates for Docker.
+type dockerTemplateData struct {
+	Validator   validatorTemplateData
+	Access      accessTemplateData
+	ClientIPVar string
+}
+
+// DockerProvider runs validator deployments on a local Docker daemon using the
+// same rendered artifacts as the cloud providers.
+type DockerProvider struct {
+	// Host is a Docker daemon address such as unix:///var/run/docker.sock or
+	// tcp://127.0.0.1:2375. Defaults to DOCKER_HOST, then the local unix socket.
+	Host string
+	// HostPort is used when Config.HostPort is unset. When both are zero,
+	// Deploy takes 8080 if it is free and any free port otherwise.
+	HostPort int
+}
+
+func NewDockerProvider() *DockerProvider {
+	return &DockerProvider{}
+}
+
+func (p *DockerProvider) Deploy(ctx context.Context, cfg *Config) (*Deployment, error) {
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	if strings.TrimSpace(cfg.Name) == "" {
+		return nil, errors.New("deployment name is required")
+	}
+
+	projectDir := cfg.ProjectDir
+	if projectDir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("get working directory: %w", err)
+		}
+		projectDir = wd
+	}
+	artifactsDir := filepath.Join(projectDir, ".sol-cloud", "deployments", cfg.Name)
+	reportStep(cfg, "Preparing deployment artifacts")
+	if err := os.MkdirAll(artifactsDir, 0o755); err != nil {
+		return nil, fmt.Errorf("create artifacts directory: %w", err)
+	}
+	if cfg.ReuseArtifacts {
+		if err := checkRenderedArtifacts(artifactsDir, "Dockerfile", "nginx.conf", "entrypoint.sh"); err != nil {
+			return nil, err
+		}
+		reportStep(cfg, "Reusing rendered artifacts")
+	} else if err := p.renderArtifacts(cfg, projectDir, artifactsDir); err != nil {
+		return nil, err
+	}
+
+	hostPort, err := p.hostPort(cfg)
+	if err != nil {
+		return nil, err
+	}
+	deployment := &Deployment{
+		Name:         cfg.Name,
+		RPCURL:       fmt.Sprintf("http://127.0.0.1:%d", hostPort),
+		WebSocketURL: fmt.Sprintf("ws://127.0.0.1:%d", hostPort),
+		Provider:     "docker",
+		ArtifactsDir: artifactsDir,
+		HostPort:     hostPort,
+	}
+
+	if cfg.DryRun {
+		return deployment, nil
+	}
+
+	reportStep(cfg, "Building image, volume, and container on local Docker")
+	deployOutput, err := p.deployViaAPI(ctx, cfg, artifactsDir, hostPort)
+	logPath := filepath.Join(artifactsDir, "deploy.log")
+	if strings.TrimSpace(deployOutput) != "" {
+		if writeErr := os.WriteFile(logPath, []byte(deployOutput), 0o644); writeErr != nil {
+			if err != nil {
+				return nil, fmt.Errorf("%w (also failed to write deploy log: %v)", err, writeErr)
+			}
+			return nil, fmt.Errorf("write deploy log: %w", writeErr)
+		}
+	}
+	if err != nil {
+		if strings.TrimSpace(deployOutput) != "" {
+			return nil, fmt.Errorf("%w\nsee deploy log: %s", err, logPath)
+		}
+		return nil, err
+	}
+
+	if !cfg.SkipHealthCheck {
+		timeout := cfg.HealthCheckTimeout
+		if timeout <= 0 {
+			timeout = defaultHealthCheck
+		}
+		interval := cfg.HealthCheckInterval
+		if interval <= 0 {
+			interval = defaultPollInterval
+		}
+		reportStep(cfg, "Waiting for RPC health")
+		if err := waitForRPCHealthy(ctx, cfg.Access.AuthorizeURL(deployment.RPCURL), timeout, interval); err != nil {
+			return nil, fmt.Errorf("deployment completed but RPC health check failed: %w", err)
+		}
+	} else {
+		reportStep(cfg, "Skipped RPC health check")
+	}
+
+	return deployment, nil
+}
+
+// renderArtifacts stages program and account fixtures and renders the
+// provider templates into artifactsDir.
+func (p *DockerProvider) renderArtifacts(cfg *Config, projectDir, artifactsDir string) error {
+	programDir := filepath.Join(artifactsDir, "program")
+	if err := os.MkdirAll(programDir, 0o755); err != nil {
+		return fmt.Errorf("create program artifacts directory: %w", err)
+	}
+
+	programDeployData, err := prepareProgramDeployData(projectDir, programDir, cfg)
+	if err != nil {
+		return err
+	}
+	accountFixtures, err := prepareAccountFixtureData(projectDir, filepath.Join(artifactsDir, "accounts"), cfg)
+	if err != nil {
+		return err
+	}
+
+	data := dockerTemplateData{
+		Access: toAccessTemplateData(cfg.Access),
+		// Nothing proxies the published port, so only the peer address is real.
+		ClientIPVar: "remote_addr",
+		Validator:   newValidatorTemplateData(cfg, accountFixtures, programDeployData),
+	}
+
+	// Docker builds straight from the artifact directory; no fly.toml needed.
+	templateTargets := []struct {
+		Name string
+		Dst  string
+	}{
+		{Name: "Dockerfile.tmpl", Dst: filepath.Join(artifactsDir, "Dockerfile")},
+		{Name: "nginx.conf.tmpl", Dst: filepath.Join(artifactsDir, "nginx.conf")},
+		{Name: "entrypoint.sh.tmpl", Dst: filepath.Join(artifactsDir, "entrypoint.sh")},
+	}
+	for _, target := range templateTargets {
+		reportDetail(cfg, "Rendering "+filepath.Base(target.Dst))
+		if err := renderEmbeddedTemplateFile(target.Name, target.Dst, data); err != nil {
+			return err
+		}
+	}
+	reportStep(cfg, "Rendered provider templates")
+	return nil
+}
+
+func (p *DockerProvider) Destroy(ctx context.Context, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("deployment name is required")
+	}
+	if err := p.removeContainer(ctx, name); err != nil {
+		return fmt.Errorf("remove docker container: %w", err)
+	}
+	if err := p.removeVolume(ctx, dockerVolumeName(name)); err != nil {
+		return fmt.Errorf("remove docker volume: %w", err)
+	}
+	if err := p.removeImage(ctx, dockerImageRef(name)); err != nil {
+		return fmt.Errorf("remove docker image: %w", err)
+	}
+	return nil
+}
+
+func (p *DockerProvider) Status(ctx context.Context, name string) (*Status, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, errors.New("deployment name is required")
+	}
+	state, err := p.containerState(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return &Status{Name: name, State: state}, nil
+}
+
+func (p *DockerProvider) Restart(ctx context.Context, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("deployment name is required")
+	}
+	if err := p.restartContainer(ctx, name); err != nil {
+		return fmt.Errorf("restart docker container: %w", err)
+	}
+	return nil
+}
+
+// ResetLedger sends SIGUSR1 to the container; the entrypoint wipes the ledger
+// volume and restarts solana-test-validator in place.
+func (p *DockerProvider) ResetLedger(ctx context.Context, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("deployment name is required")
+	}
+	if err := p.signalContainer(ctx, name, "SIGUSR1"); err != nil {
+		return fmt.Errorf("signal docker container: %w", err)
+	}
+	return nil
+}
+
+func (p *DockerProvider) Logs(ctx context.Context, name string, opts LogOptions) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("deployment name is required")
+	}
+	err := p.streamContainerLogs(ctx, name, opts.Follow, opts.Since, newLogFilter(opts))
+	if err != nil && ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// hostPort returns the localhost port to publish the RPC on. Redeploys pass
+// the port from local state so a deployment keeps its URL.
+func (p *DockerProvider) hostPort(cfg *Config) (int, error) {
+	switch {
+	case cfg.HostPort > 0:
+		return cfg.HostPort, nil
+	case p.HostPort > 0:
+		return p.HostPort, nil
+	}
+	if listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", defaultDockerHostPort)); err == nil {
+		_ = listener.Close()
+		return defaultDockerHostPort, nil
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("find a free host port: %w", err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// daemonEndpoint returns the network and address used to reach the Docker daemon.
+func (p *DockerProvider) daemonEndpoint() (string, string, error) {
+	host := strings.TrimSpace(p.Host)
+	if host == "" {
+		host = strings.TrimSpace(os.Getenv("DOCKER_HOST"))
+	}
+	if host == "" {
+		return "unix", defaultDockerSocket, nil
+	}
+	switch {
+	case strings.HasPrefix(host, "unix://"):
+		return "unix", strings.TrimPrefix(host, "unix://"), nil
+	case strings.HasPrefix(host, "tcp://"):
+		return "tcp", strings.TrimPrefix(host, "tcp://"), nil
+	default:
+		return "", "", fmt.Errorf("unsupported DOCKER_HOST %q: use unix:// or tcp://", host)
+	}
+}
+
+// httpClient returns a client that dials the Docker daemon regardless of the
+// request host. A zero timeout is used for streaming requests like build/logs.
+func (p *DockerProvider) httpClient(timeout time.Duration) (*http.Client, error) {
+	network, address, err := p.daemonEndpoint()
+	if err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, address)
+		},
+	}
+	return &http.Client{Transport: transport, Timeout: timeout}, nil
+}
+
+func dockerImageRef(name string) string {
+	return dockerImagePrefix + name + ":latest"
+}
+
+func dockerVolumeName(name string) string {
+	return name + "_ledger"
+}
@@ -0,0 +1,471 @@
+package providers
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dockerAPIBase is a placeholder host; the transport always dials the daemon socket.
+const dockerAPIBase = "http://docker"
+
+const ledgerMountPath = "/var/lib/solana/ledger"
+
+type dockerContainerCreateRequest struct {
+	Image        string                    `json:"Image"`
+	ExposedPorts map[string]struct{}       `json:"ExposedPorts,omitempty"`
+	Labels       map[string]string         `json:"Labels,omitempty"`
+	Env          []string                  `json:"Env,omitempty"`
+	HostConfig   dockerContainerHostConfig `json:"HostConfig"`
+}
+
+type dockerContainerHostConfig struct {
+	Binds         []string                       `json:"Binds,omitempty"`
+	PortBindings  map[string][]dockerPortBinding `json:"PortBindings,omitempty"`
+	RestartPolicy dockerRestartPolicy            `json:"RestartPolicy"`
+}
+
+type dockerPortBinding struct {
+	HostIP   string `json:"HostIp"`
+	HostPort string `json:"HostPort"`
+}
+
+type dockerRestartPolicy struct {
+	Name              string `json:"Name"`
+	MaximumRetryCount int    `json:"MaximumRetryCount,omitempty"`
+}
+
+func (p *DockerProvider) deployViaAPI(ctx context.Context, cfg *Config, artifactsDir string, hostPort int) (string, error) {
+	var logs strings.Builder
+	logs.WriteString("docker deploy started\n")
+	logs.WriteString(fmt.Sprintf("container=%s image=%s\n", cfg.Name, dockerImageRef(cfg.Name)))
+
+	if err := p.ping(ctx); err != nil {
+		return logs.String(), err
+	}
+
+	buildOutput, err := p.buildImage(ctx, artifactsDir, dockerImageRef(cfg.Name))
+	logs.WriteString("\n[docker build]\n")
+	logs.WriteString(buildOutput)
+	if err != nil {
+		return logs.String(), commandStageError("docker build", err, buildOutput)
+	}
+
+	binds := []string(nil)
+	if !cfg.SkipVolume {
+		volumeName := dockerVolumeName(cfg.Name)
+		if err := p.ensureVolume(ctx, volumeName); err != nil {
+			return logs.String(), err
+		}
+		binds = append(binds, volumeName+":"+ledgerMountPath)
+		logs.WriteString(fmt.Sprintf("volume ensured: %s\n", volumeName))
+	} else {
+		logs.WriteString("volume creation skipped (using ephemeral storage)\n")
+	}
+
+	// The container is recreated on every deploy so it picks up the new image;
+	// the named volume keeps the ledger across deploys.
+	if err := p.removeContainer(ctx, cfg.Name); err != nil {
+		return logs.String(), err
+	}
+	if err := p.createContainer(ctx, cfg.Name, dockerImageRef(cfg.Name), binds, hostPort); err != nil {
+		return logs.String(), err
+	}
+	logs.WriteString("container created\n")
+	if err := p.startContainer(ctx, cfg.Name); err != nil {
+		return logs.String(), err
+	}
+	logs.WriteString(fmt.Sprintf("container started: port %d -> 8080\n", hostPort))
+	return logs.String(), nil
+}
+
+func (p *DockerProvider) ping(ctx context.Context) error {
+	status, body, err := p.doDockerRequest(ctx, http.MethodGet, "/_ping", nil)
+	if err != nil {
+		return fmt.Errorf("docker daemon not reachable (is Docker running?): %w", err)
+	}
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("docker ping failed (%d): %s", status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// buildImage sends the artifact directory as a tar build context and returns
+// the streamed build output.
+func (p *DockerProvider) buildImage(ctx context.Context, artifactsDir, imageRef string) (string, error) {
+	buildContext, err := tarDirectory(artifactsDir)
+	if err != nil {
+		return "", fmt.Errorf("create docker build context: %w", err)
+	}
+
+	query := url.Values{}
+	query.Set("t", imageRef)
+	query.Set("rm", "1")
+	query.Set("forcerm", "1")
+	// The Dockerfile installs the x86_64 Solana release.
+	query.Set("platform", "linux/amd64")
+
+	client, err := p.httpClient(0)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dockerAPIBase+"/build?"+query.Encode(), buildContext)
+	if err != nil {
+		return "", fmt.Errorf("build docker build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-tar")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("docker build request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 8*1024))
+		return "", fmt.Errorf("docker build returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var output strings.Builder
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var message struct {
+			Stream string `json:"stream"`
+			Status string `json:"status"`
+			Error  string `json:"error"`
+		}
+		if err := decoder.Decode(&message); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return output.String(), fmt.Errorf("decode docker build output: %w", err)
+		}
+		switch {
+		case message.Error != "":
+			output.WriteString(message.Error + "\n")
+			return output.String(), errors.New(strings.TrimSpace(message.Error))
+		case message.Stream != "":
+			output.WriteString(message.Stream)
+		case message.Status != "":
+			output.WriteString(message.Status + "\n")
+		}
+	}
+	return output.String(), nil
+}
+
+func (p *DockerProvider) ensureVolume(ctx context.Context, volumeName string) error {
+	status, body, err := p.doDockerRequest(ctx, http.MethodPost, "/volumes/create", map[string]any{
+		"Name":   volumeName,
+		"Labels": map[string]string{"sol-cloud": "ledger"},
+	})
+	if err != nil {
+		return err
+	}
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("create volume %s failed (%d): %s", volumeName, status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+func (p *DockerProvider) createContainer(ctx context.Context, name, imageRef string, binds []string, hostPort int) error {
+	req := dockerContainerCreateRequest{
+		Image:        imageRef,
+		ExposedPorts: map[string]struct{}{"8080/tcp": {}},
+		Labels:       map[string]string{"sol-cloud": name},
+		HostConfig: dockerContainerHostConfig{
+			Binds: binds,
+			PortBindings: map[string][]dockerPortBinding{
+				"8080/tcp": {{HostIP: "127.0.0.1", HostPort: strconv.Itoa(hostPort)}},
+			},
+			RestartPolicy: dockerRestartPolicy{Name: "on-failure", MaximumRetryCount: 5},
+		},
+	}
+	status, body, err := p.doDockerRequest(ctx, http.MethodPost, "/containers/create?name="+url.QueryEscape(name), req)
+	if err != nil {
+		return err
+	}
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("create container %s failed (%d): %s", name, status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+func (p *DockerProvider) startContainer(ctx context.Context, name string) error {
+	status, body, err := p.doDockerRequest(ctx, http.MethodPost, "/containers/"+url.PathEscape(name)+"/start", nil)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusNotModified || (status >= 200 && status < 300) {
+		return nil
+	}
+	return fmt.Errorf("start container %s failed (%d): %s", name, status, strings.TrimSpace(string(body)))
+}
+
+func (p *DockerProvider) restartContainer(ctx context.Context, name string) error {
+	status, body, err := p.doDockerRequest(ctx, http.MethodPost, "/containers/"+url.PathEscape(name)+"/restart?t=30", nil)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusNotFound {
+		return fmt.Errorf("container %q not found", name)
+	}
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("restart container %s failed (%d): %s", name, status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+func (p *DockerProvider) signalContainer(ctx context.Context, name, signal string) error {
+	path := "/containers/" + url.PathEscape(name) + "/kill?signal=" + url.QueryEscape(signal)
+	status, body, err := p.doDockerRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusNotFound {
+		return fmt.Errorf("container %q not found", name)
+	}
+	if status == http.StatusConflict {
+		return fmt.Errorf("container %q is not running", name)
+	}
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("signal container %s failed (%d): %s", name, status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+func (p *DockerProvider) removeContainer(ctx context.Context, name string) error {
+	status, body, err := p.doDockerRequest(ctx, http.MethodDelete, "/containers/"+url.PathEscape(name)+"?force=true", nil)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusNotFound || (status >= 200 && status < 300) {
+		return nil
+	}
+	return fmt.Errorf("remove container %s failed (%d): %s", name, status, strings.TrimSpace(string(body)))
+}
+
+func (p *DockerProvider) removeVolume(ctx context.Context, volumeName string) error {
+	status, body, err := p.doDockerRequest(ctx, http.MethodDelete, "/volumes/"+url.PathEscape(volumeName), nil)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusNotFound || (status >= 200 && status < 300) {
+		return nil
+	}
+	return fmt.Errorf("remove volume %s failed (%d): %s", volumeName, status, strings.TrimSpace(string(body)))
+}
+
+func (p *DockerProvider) removeImage(ctx context.Context, imageRef string) error {
+	status, body, err := p.doDockerRequest(ctx, http.MethodDelete, "/images/"+url.PathEscape(imageRef), nil)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusNotFound || (status >= 200 && status < 300) {
+		return nil
+	}
+	return fmt.Errorf("remove image %s failed (%d): %s", imageRef, status, strings.TrimSpace(string(body)))
+}
+
+func (p *DockerProvider) containerState(ctx context.Context, name string) (string, error) {
+	status, body, err := p.doDockerRequest(ctx, http.MethodGet, "/containers/"+url.PathEscape(name)+"/json", nil)
+	if err != nil {
+		return "unknown", err
+	}
+	if status == http.StatusNotFound {
+		return "stopped", fmt.Errorf("container %q not found", name)
+	}
+	if status < 200 || status >= 300 {
+		return "unknown", fmt.Errorf("inspect container failed (%d): %s", status, strings.TrimSpace(string(body)))
+	}
+
+	var inspect struct {
+		State struct {
+			Status string `json:"Status"`
+		} `json:"State"`
+	}
+	if err := json.Unmarshal(body, &inspect); err != nil {
+		return "unknown", fmt.Errorf("decode container inspect: %w", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(inspect.State.Status)) {
+	case "running":
+		return "running", nil
+	case "created", "restarting":
+		return "starting", nil
+	case "exited", "dead", "paused", "removing":
+		return "stopped", nil
+	case "":
+		return "unknown", nil
+	default:
+		return strings.ToLower(inspect.State.Status), nil
+	}
+}
+
+// streamContainerLogs reads the multiplexed stdout/stderr log stream for a container.
+func (p *DockerProvider) streamContainerLogs(ctx context.Context, name string, follow bool, since time.Duration, filter *logFilter) error {
+	query := url.Values{}
+	query.Set("stdout", "1")
+	query.Set("stderr", "1")
+	query.Set("timestamps", "1")
+	if follow {
+		query.Set("follow", "1")
+	}
+	if since > 0 {
+		query.Set("since", strconv.FormatInt(time.Now().Add(-since).Unix(), 10))
+	}
+
+	client, err := p.httpClient(0)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, dockerAPIBase+"/containers/"+url.PathEscape(name)+"/logs?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("build logs request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("logs request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("container %q not found", name)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 8*1024))
+		return fmt.Errorf("logs request returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	header := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil
+			}
+			return fmt.Errorf("read logs stream: %w", err)
+		}
+		size := binary.BigEndian.Uint32(header[4:8])
+		frame := make([]byte, size)
+		if _, err := io.ReadFull(reader, frame); err != nil {
+			return fmt.Errorf("read logs stream: %w", err)
+		}
+		for _, line := range strings.Split(strings.TrimRight(string(frame), "\n"), "\n") {
+			filter.Emit(parseDockerLogLine(name, line))
+		}
+	}
+}
+
+func parseDockerLogLine(name, line string) LogEntry {
+	line = strings.TrimRight(line, "\r")
+	entry := LogEntry{Instance: name, Message: line}
+	stamp, rest, ok := strings.Cut(line, " ")
+	if !ok {
+		return entry
+	}
+	ts, err := time.Parse(time.RFC3339Nano, stamp)
+	if err != nil {
+		return entry
+	}
+	entry.Timestamp = ts
+	entry.Message = rest
+	return entry
+}
+
+func (p *DockerProvider) doDockerRequest(ctx context.Context, method, path string, payload any) (int, []byte, error) {
+	var bodyReader io.Reader
+	if payload != nil {
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return 0, nil, fmt.Errorf("marshal request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(body)
+	}
+
+	client, err := p.httpClient(defaultDockerHTTPTimeout)
+	if err != nil {
+		return 0, nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, dockerAPIBase+path, bodyReader)
+	if err != nil {
+		return 0, nil, fmt.Errorf("build request %s %s: %w", method, path, err)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("request failed %s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 8*1024*1024))
+	if err != nil {
+		return 0, nil, fmt.Errorf("read response %s %s: %w", method, path, err)
+	}
+	return resp.StatusCode, body, nil
+}
+
+// tarDirectory archives a directory into an in-memory tar stream for the
+// Docker build API. deploy.log is skipped so it never invalidates the cache.
+func tarDirectory(root string) (io.Reader, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." || rel == "deploy.log" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if d.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if d.IsDir() || !info.Mode().IsRegular() {
+			return nil
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, err = io.Copy(tw, file)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
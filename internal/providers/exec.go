@@ -11,6 +11,9 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/CharlieAIO/sol-cloud/internal/solana"
+	"github.com/CharlieAIO/sol-cloud/internal/validator"
+
 	tmplassets "github.com/CharlieAIO/sol-cloud/templates"
 )
 
@@ -76,51 +79,263 @@ func renderEmbeddedTemplateFile(name, dst string, data any) error {
 	return nil
 }
 
-func prepareProgramDeployData(projectDir, programDir string, cfg *Config) (programDeployTemplateData, error) {
+func prepareProgramDeployData(projectDir, programDir string, cfg *Config) ([]programDeployTemplateData, error) {
+	if cfg == nil {
+		return nil, errors.New("config is required")
+	}
+	// Rebuild the directory so programs removed from config do not linger in the image.
+	if err := os.RemoveAll(programDir); err != nil {
+		return nil, fmt.Errorf("clear program artifacts directory: %w", err)
+	}
+	if err := os.MkdirAll(programDir, 0o755); err != nil {
+		return nil, fmt.Errorf("create program artifacts directory: %w", err)
+	}
+
+	var out []programDeployTemplateData
+	for i, programCfg := range cfg.Validator.ProgramDeploys {
+		if !programCfg.HasValues() {
+			continue
+		}
+		if !programCfg.Enabled() {
+			return nil, fmt.Errorf("program deploy config %d is incomplete", i+1)
+		}
+
+		soSrc, err := resolveProjectPath(projectDir, programCfg.SOPath)
+		if err != nil {
+			return nil, fmt.Errorf("resolve program_deploy.so_path: %w", err)
+		}
+
+		// Each program gets its own subdirectory, prefixed by position so the
+		// declared deploy order is visible in the artifacts.
+		subdir := fmt.Sprintf("%02d-%s", i+1, programArtifactName(soSrc))
+		if err := os.MkdirAll(filepath.Join(programDir, subdir), 0o755); err != nil {
+			return nil, fmt.Errorf("create program artifacts directory: %w", err)
+		}
+		if err := copyFile(soSrc, filepath.Join(programDir, subdir, "program.so")); err != nil {
+			return nil, fmt.Errorf("copy program binary: %w", err)
+		}
+
+		imageDir := "/opt/sol-cloud/program/" + subdir
+		programID, err := stageProgramKey(projectDir, programCfg.ProgramIDKeypairPath, programCfg.IsGenesis(),
+			filepath.Join(programDir, subdir, "program-id-keypair.json"), imageDir+"/program-id-keypair.json")
+		if err != nil {
+			return nil, fmt.Errorf("program_deploy.program_id_keypair: %w", err)
+		}
+		upgradeAuthority := ""
+		if strings.TrimSpace(programCfg.UpgradeAuthorityPath) != "" {
+			upgradeAuthority, err = stageProgramKey(projectDir, programCfg.UpgradeAuthorityPath, programCfg.IsGenesis(),
+				filepath.Join(programDir, subdir, "upgrade-authority.json"), imageDir+"/upgrade-authority.json")
+			if err != nil {
+				return nil, fmt.Errorf("program_deploy.upgrade_authority: %w", err)
+			}
+		}
+
+		out = append(out, programDeployTemplateData{
+			Genesis:              programCfg.IsGenesis(),
+			SOPath:               imageDir + "/program.so",
+			ProgramIDKeypairPath: programID,
+			UpgradeAuthorityPath: upgradeAuthority,
+		})
+	}
+	return out, nil
+}
+
+// stageProgramKey copies a keypair file into the program artifacts and returns
+// its in-image path. Genesis-mode entries may name a pubkey instead, which is
+// passed through to solana-test-validator unchanged.
+func stageProgramKey(projectDir, value string, allowPubkey bool, dst, imagePath string) (string, error) {
+	value = strings.TrimSpace(value)
+	if allowPubkey && validator.IsAddress(value) {
+		return value, nil
+	}
+	src, err := resolveProjectPath(projectDir, value)
+	if err != nil {
+		return "", fmt.Errorf("resolve keypair: %w", err)
+	}
+	if err := copyFile(src, dst); err != nil {
+		return "", fmt.Errorf("copy keypair: %w", err)
+	}
+	return imagePath, nil
+}
+
+// programArtifactName derives a filesystem-safe directory name from a .so path.
+func programArtifactName(soPath string) string {
+	base := strings.TrimSuffix(filepath.Base(soPath), filepath.Ext(soPath))
+	var b strings.Builder
+	for _, r := range base {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	if b.Len() == 0 {
+		return "program"
+	}
+	return b.String()
+}
+
+// prepareAccountFixtureData copies configured account snapshots into
+// accountsDir as <address>.json, synthesizes token fixture accounts next to
+// them, and returns their in-image paths. The directory is rebuilt on every
+// deploy so removed fixtures do not linger in the image.
+func prepareAccountFixtureData(projectDir, accountsDir string, cfg *Config) ([]accountFixtureTemplateData, error) {
 	if cfg == nil {
-		return programDeployTemplateData{}, errors.New("config is required")
+		return nil, errors.New("config is required")
+	}
+	if err := os.RemoveAll(accountsDir); err != nil {
+		return nil, fmt.Errorf("clear account artifacts directory: %w", err)
+	}
+	if err := os.MkdirAll(accountsDir, 0o755); err != nil {
+		return nil, fmt.Errorf("create account artifacts directory: %w", err)
 	}
 
-	programCfg := cfg.Validator.ProgramDeploy
-	if !programCfg.HasValues() {
-		return programDeployTemplateData{}, nil
+	type source struct {
+		address string
+		path    string
+	}
+	var sources []source
+	for _, fixture := range cfg.Validator.Accounts {
+		if dir := strings.TrimSpace(fixture.Dir); dir != "" {
+			dirPath := dir
+			if !filepath.IsAbs(dirPath) {
+				dirPath = filepath.Join(projectDir, dirPath)
+			}
+			entries, err := os.ReadDir(dirPath)
+			if err != nil {
+				return nil, fmt.Errorf("read accounts dir %s: %w", dir, err)
+			}
+			found := 0
+			for _, entry := range entries {
+				address, ok := strings.CutSuffix(entry.Name(), ".json")
+				if entry.IsDir() || !ok || !validator.IsAddress(address) {
+					continue
+				}
+				sources = append(sources, source{address: address, path: filepath.Join(dirPath, entry.Name())})
+				found++
+			}
+			if found == 0 {
+				return nil, fmt.Errorf("accounts dir %s contains no <address>.json snapshots", dir)
+			}
+			continue
+		}
+		path, err := resolveProjectPath(projectDir, fixture.Path)
+		if err != nil {
+			return nil, fmt.Errorf("resolve accounts path for %s: %w", fixture.Address, err)
+		}
+		sources = append(sources, source{address: strings.TrimSpace(fixture.Address), path: path})
 	}
-	if !programCfg.Enabled() {
-		return programDeployTemplateData{}, errors.New("program deploy config is incomplete")
+
+	seen := map[string]struct{}{}
+	out := make([]accountFixtureTemplateData, 0, len(sources))
+	for _, src := range sources {
+		if _, ok := seen[src.address]; ok {
+			return nil, fmt.Errorf("account %s is configured more than once in validator.accounts", src.address)
+		}
+		seen[src.address] = struct{}{}
+		if err := copyFile(src.path, filepath.Join(accountsDir, src.address+".json")); err != nil {
+			return nil, fmt.Errorf("copy account snapshot %s: %w", src.address, err)
+		}
+		out = append(out, accountFixtureTemplateData{
+			Address: src.address,
+			Path:    "/opt/sol-cloud/accounts/" + src.address + ".json",
+		})
 	}
 
-	soSrc, err := resolveProjectPath(projectDir, programCfg.SOPath)
+	for i, fixture := range cfg.Validator.TokenFixtures {
+		accounts, err := tokenFixtureAccounts(projectDir, fixture)
+		if err != nil {
+			return nil, fmt.Errorf("token_fixtures[%d]: %w", i, err)
+		}
+		for _, account := range accounts {
+			if _, ok := seen[account.address]; ok {
+				return nil, fmt.Errorf("token_fixtures[%d]: account %s is already configured in validator.accounts or another token fixture", i, account.address)
+			}
+			seen[account.address] = struct{}{}
+			if err := os.WriteFile(filepath.Join(accountsDir, account.address+".json"), account.data, 0o644); err != nil {
+				return nil, fmt.Errorf("write token fixture %s: %w", account.address, err)
+			}
+			out = append(out, accountFixtureTemplateData{
+				Address: account.address,
+				Path:    "/opt/sol-cloud/accounts/" + account.address + ".json",
+			})
+		}
+	}
+	return out, nil
+}
+
+type syntheticAccount struct {
+	address string
+	data    []byte
+}
+
+// tokenFixtureAccounts renders the mint and one associated token account per
+// holder as --account snapshots.
+func tokenFixtureAccounts(projectDir string, fixture validator.TokenFixture) ([]syntheticAccount, error) {
+	mint, err := resolvePublicKey(projectDir, fixture.Mint)
 	if err != nil {
-		return programDeployTemplateData{}, fmt.Errorf("resolve program_deploy.so_path: %w", err)
+		return nil, fmt.Errorf("mint: %w", err)
 	}
-	programIDKeypairSrc, err := resolveProjectPath(projectDir, programCfg.ProgramIDKeypairPath)
+	supply, err := validator.TokenFixtureSupply(fixture)
 	if err != nil {
-		return programDeployTemplateData{}, fmt.Errorf("resolve program_deploy.program_id_keypair: %w", err)
+		return nil, err
+	}
+	mintData := solana.Mint{Supply: supply, Decimals: fixture.Decimals}
+	if strings.TrimSpace(fixture.MintAuthority) != "" {
+		authority, err := resolvePublicKey(projectDir, fixture.MintAuthority)
+		if err != nil {
+			return nil, fmt.Errorf("mint_authority: %w", err)
+		}
+		mintData.MintAuthority = &authority
+	}
+	if strings.TrimSpace(fixture.FreezeAuthority) != "" {
+		authority, err := resolvePublicKey(projectDir, fixture.FreezeAuthority)
+		if err != nil {
+			return nil, fmt.Errorf("freeze_authority: %w", err)
+		}
+		mintData.FreezeAuthority = &authority
 	}
-	upgradeAuthoritySrc, err := resolveProjectPath(projectDir, programCfg.UpgradeAuthorityPath)
+	data, err := solana.MintAccountJSON(mint, mintData)
 	if err != nil {
-		return programDeployTemplateData{}, fmt.Errorf("resolve program_deploy.upgrade_authority: %w", err)
+		return nil, fmt.Errorf("encode mint: %w", err)
 	}
+	accounts := []syntheticAccount{{address: mint.String(), data: data}}
 
-	soDest := filepath.Join(programDir, "program.so")
-	if err := copyFile(soSrc, soDest); err != nil {
-		return programDeployTemplateData{}, fmt.Errorf("copy program binary: %w", err)
+	for _, holder := range fixture.Holders {
+		owner, err := solana.ParsePublicKey(strings.TrimSpace(holder.Address))
+		if err != nil {
+			return nil, fmt.Errorf("holder: %w", err)
+		}
+		amount, err := validator.ParseTokenAmount(holder.Amount, fixture.Decimals)
+		if err != nil {
+			return nil, fmt.Errorf("holder %s: %w", holder.Address, err)
+		}
+		address, err := solana.AssociatedTokenAddress(owner, mint)
+		if err != nil {
+			return nil, fmt.Errorf("derive token account for %s: %w", holder.Address, err)
+		}
+		data, err := solana.TokenAccountJSON(address, solana.TokenAccount{Mint: mint, Owner: owner, Amount: amount})
+		if err != nil {
+			return nil, fmt.Errorf("encode token account for %s: %w", holder.Address, err)
+		}
+		accounts = append(accounts, syntheticAccount{address: address.String(), data: data})
 	}
-	programIDKeypairDest := filepath.Join(programDir, "program-id-keypair.json")
-	if err := copyFile(programIDKeypairSrc, programIDKeypairDest); err != nil {
-		return programDeployTemplateData{}, fmt.Errorf("copy program id keypair: %w", err)
+	return accounts, nil
+}
+
+// resolvePublicKey accepts a pubkey or a keypair file path relative to the
+// project directory and returns the public key.
+func resolvePublicKey(projectDir, value string) (solana.PublicKey, error) {
+	value = strings.TrimSpace(value)
+	if validator.IsAddress(value) {
+		return solana.ParsePublicKey(value)
 	}
-	upgradeAuthorityDest := filepath.Join(programDir, "upgrade-authority.json")
-	if err := copyFile(upgradeAuthoritySrc, upgradeAuthorityDest); err != nil {
-		return programDeployTemplateData{}, fmt.Errorf("copy upgrade authority keypair: %w", err)
+	path, err := resolveProjectPath(projectDir, value)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("resolve keypair: %w", err)
 	}
-
-	return programDeployTemplateData{
-		Enabled:              true,
-		SOPath:               "/opt/sol-cloud/program/program.so",
-		ProgramIDKeypairPath: "/opt/sol-cloud/program/program-id-keypair.json",
-		UpgradeAuthorityPath: "/opt/sol-cloud/program/upgrade-authority.json",
-	}, nil
+	return solana.ReadKeypairPublicKey(path)
 }
 
 func resolveProjectPath(projectDir, pathValue string) (string, error) {
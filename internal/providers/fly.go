@@ -22,6 +22,8 @@ const (
 	defaultPollInterval   = 5 * time.Second
 	defaultFlyMachinesAPI = "https://api.machines.dev/v1"
 	defaultFlyGraphQLURL  = "https://api.fly.io/graphql"
+	defaultFlyLogsAPI     = "https://api.fly.io/api/v1"
+	defaultLogsPoll       = 2 * time.Second
 	defaultHTTPTimeout    = 30 * time.Second
 	flyAuthProbeAppName   = "sol-cloud-auth-probe"
 )
@@ -35,12 +37,14 @@ type FlyProvider struct {
 	HTTPClient         *http.Client
 	MachinesAPIBaseURL string
 	GraphQLURL         string
+	LogsAPIBaseURL     string
 }
 
 func NewFlyProvider() *FlyProvider {
 	return &FlyProvider{
 		MachinesAPIBaseURL: defaultFlyMachinesAPI,
 		GraphQLURL:         defaultFlyGraphQLURL,
+		LogsAPIBaseURL:     defaultFlyLogsAPI,
 	}
 }
 
@@ -49,6 +53,10 @@ type flyTemplateData struct {
 	Region     string
 	SkipVolume bool
 	Validator  validatorTemplateData
+	Access     accessTemplateData
+	// ClientIPVar is the nginx variable holding the client address set by
+	// the platform's edge proxy.
+	ClientIPVar string
 }
 
 func (p *FlyProvider) Deploy(ctx context.Context, cfg *Config) (*Deployment, error) {
@@ -75,52 +83,14 @@ func (p *FlyProvider) Deploy(ctx context.Context, cfg *Config) (*Deployment, err
 	if err := os.MkdirAll(artifactsDir, 0o755); err != nil {
 		return nil, fmt.Errorf("create artifacts directory: %w", err)
 	}
-	programDir := filepath.Join(artifactsDir, "program")
-	if err := os.MkdirAll(programDir, 0o755); err != nil {
-		return nil, fmt.Errorf("create program artifacts directory: %w", err)
-	}
-
-	programDeployData, err := prepareProgramDeployData(projectDir, programDir, cfg)
-	if err != nil {
-		return nil, err
-	}
-
-	data := flyTemplateData{
-		Name:       cfg.Name,
-		Region:     cfg.Region,
-		SkipVolume: cfg.SkipVolume,
-		Validator: validatorTemplateData{
-			SlotsPerEpoch:            cfg.Validator.SlotsPerEpoch,
-			TicksPerSlot:             cfg.Validator.TicksPerSlot,
-			ComputeUnitLimit:         cfg.Validator.ComputeUnitLimit,
-			LedgerLimitSize:          cfg.Validator.LedgerLimitSize,
-			LedgerDiskLimitGB:        cfg.Validator.LedgerDiskLimitGB,
-			CloneRPCURL:              cfg.Validator.CloneRPCURL,
-			ClonePrograms:            append([]string(nil), cfg.Validator.ClonePrograms...),
-			CloneAccounts:            append([]string(nil), cfg.Validator.CloneAccounts...),
-			CloneUpgradeablePrograms: append([]string(nil), cfg.Validator.CloneUpgradeablePrograms...),
-			AirdropAccounts:          toAirdropTemplateData(cfg.Validator.AirdropAccounts),
-			ForceReset:               cfg.Validator.ForceReset,
-			ProgramDeploy:            programDeployData,
-		},
-	}
-
-	templateTargets := []struct {
-		Name string
-		Dst  string
-	}{
-		{Name: "Dockerfile.tmpl", Dst: filepath.Join(artifactsDir, "Dockerfile")},
-		{Name: "fly.toml.tmpl", Dst: filepath.Join(artifactsDir, "fly.toml")},
-		{Name: "nginx.conf.tmpl", Dst: filepath.Join(artifactsDir, "nginx.conf")},
-		{Name: "entrypoint.sh.tmpl", Dst: filepath.Join(artifactsDir, "entrypoint.sh")},
-	}
-	for _, target := range templateTargets {
-		reportDetail(cfg, "Rendering "+filepath.Base(target.Dst))
-		if err := renderEmbeddedTemplateFile(target.Name, target.Dst, data); err != nil {
+	if cfg.ReuseArtifacts {
+		if err := checkRenderedArtifacts(artifactsDir, "Dockerfile", "fly.toml", "nginx.conf", "entrypoint.sh"); err != nil {
 			return nil, err
 		}
+		reportStep(cfg, "Reusing rendered artifacts")
+	} else if err := p.renderArtifacts(cfg, projectDir, artifactsDir); err != nil {
+		return nil, err
 	}
-	reportStep(cfg, "Rendered provider templates")
 
 	deployment := &Deployment{
 		Name:         cfg.Name,
@@ -174,7 +144,7 @@ func (p *FlyProvider) Deploy(ctx context.Context, cfg *Config) (*Deployment, err
 			interval = defaultPollInterval
 		}
 		reportStep(cfg, "Waiting for RPC health")
-		if err := waitForRPCHealthy(ctx, deployment.RPCURL, timeout, interval); err != nil {
+		if err := waitForRPCHealthy(ctx, cfg.Access.AuthorizeURL(deployment.RPCURL), timeout, interval); err != nil {
 			return nil, fmt.Errorf("deployment completed but RPC health check failed: %w", err)
 		}
 	} else {
@@ -184,6 +154,52 @@ func (p *FlyProvider) Deploy(ctx context.Context, cfg *Config) (*Deployment, err
 	return deployment, nil
 }
 
+// renderArtifacts stages program and account fixtures and renders the
+// provider templates into artifactsDir.
+func (p *FlyProvider) renderArtifacts(cfg *Config, projectDir, artifactsDir string) error {
+	programDir := filepath.Join(artifactsDir, "program")
+	if err := os.MkdirAll(programDir, 0o755); err != nil {
+		return fmt.Errorf("create program artifacts directory: %w", err)
+	}
+
+	programDeployData, err := prepareProgramDeployData(projectDir, programDir, cfg)
+	if err != nil {
+		return err
+	}
+	accountFixtures, err := prepareAccountFixtureData(projectDir, filepath.Join(artifactsDir, "accounts"), cfg)
+	if err != nil {
+		return err
+	}
+
+	data := flyTemplateData{
+		Name:       cfg.Name,
+		Region:     cfg.Region,
+		SkipVolume: cfg.SkipVolume,
+		Access:     toAccessTemplateData(cfg.Access),
+		// Fly's proxy overwrites Fly-Client-IP on every request.
+		ClientIPVar: "http_fly_client_ip",
+		Validator:   newValidatorTemplateData(cfg, accountFixtures, programDeployData),
+	}
+
+	templateTargets := []struct {
+		Name string
+		Dst  string
+	}{
+		{Name: "Dockerfile.tmpl", Dst: filepath.Join(artifactsDir, "Dockerfile")},
+		{Name: "fly.toml.tmpl", Dst: filepath.Join(artifactsDir, "fly.toml")},
+		{Name: "nginx.conf.tmpl", Dst: filepath.Join(artifactsDir, "nginx.conf")},
+		{Name: "entrypoint.sh.tmpl", Dst: filepath.Join(artifactsDir, "entrypoint.sh")},
+	}
+	for _, target := range templateTargets {
+		reportDetail(cfg, "Rendering "+filepath.Base(target.Dst))
+		if err := renderEmbeddedTemplateFile(target.Name, target.Dst, data); err != nil {
+			return err
+		}
+	}
+	reportStep(cfg, "Rendered provider templates")
+	return nil
+}
+
 func (p *FlyProvider) Destroy(ctx context.Context, name string) error {
 	if strings.TrimSpace(name) == "" {
 		return errors.New("deployment name is required")
@@ -243,6 +259,75 @@ func (p *FlyProvider) Restart(ctx context.Context, name string) error {
 	return nil
 }
 
+// ResetLedger sends SIGUSR1 to the validator machine; the entrypoint wipes the
+// ledger and restarts solana-test-validator in place.
+func (p *FlyProvider) ResetLedger(ctx context.Context, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("deployment name is required")
+	}
+
+	token, err := p.resolveAccessToken()
+	if err != nil {
+		return fmt.Errorf("fly auth required: run `sol-cloud auth fly`: %w", err)
+	}
+
+	machines, err := p.listMachines(ctx, token, name)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	if len(machines) == 0 {
+		return fmt.Errorf("no machines found for app %q", name)
+	}
+
+	machineID := machines[0].ID
+	if strings.TrimSpace(machineID) == "" {
+		return errors.New("machine ID is empty")
+	}
+
+	if err := p.signalMachine(ctx, token, name, machineID, "SIGUSR1"); err != nil {
+		return fmt.Errorf("signal machine %s: %w", machineID, err)
+	}
+	return nil
+}
+
+func (p *FlyProvider) Logs(ctx context.Context, name string, opts LogOptions) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("deployment name is required")
+	}
+
+	token, err := p.resolveAccessToken()
+	if err != nil {
+		return fmt.Errorf("fly auth required: run `sol-cloud auth fly`: %w", err)
+	}
+
+	filter := newLogFilter(opts)
+	nextToken := ""
+	for {
+		entries, pageToken, err := p.fetchLogs(ctx, token, name, nextToken)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		for _, entry := range entries {
+			filter.Emit(entry)
+		}
+		if pageToken != "" {
+			nextToken = pageToken
+		}
+		if len(entries) > 0 {
+			continue
+		}
+		if !opts.Follow {
+			return nil
+		}
+		if err := sleepContext(ctx, defaultLogsPoll); err != nil {
+			return nil
+		}
+	}
+}
+
 func (p *FlyProvider) restartMachine(ctx context.Context, token, appName, machineID string) error {
 	path := fmt.Sprintf("/apps/%s/machines/%s/restart", appName, machineID)
 	status, body, err := p.doMachinesRequest(ctx, token, http.MethodPost, path, nil)
@@ -258,6 +343,21 @@ func (p *FlyProvider) restartMachine(ctx context.Context, token, appName, machin
 	return fmt.Errorf("restart machine failed (%d): %s", status, strings.TrimSpace(string(body)))
 }
 
+func (p *FlyProvider) signalMachine(ctx context.Context, token, appName, machineID, signal string) error {
+	path := fmt.Sprintf("/apps/%s/machines/%s/signal", appName, machineID)
+	status, body, err := p.doMachinesRequest(ctx, token, http.MethodPost, path, map[string]string{"signal": signal})
+	if err != nil {
+		return err
+	}
+	if status >= 200 && status < 300 {
+		return nil
+	}
+	if status == http.StatusNotFound {
+		return fmt.Errorf("machine %s not found", machineID)
+	}
+	return fmt.Errorf("signal machine failed (%d): %s", status, strings.TrimSpace(string(body)))
+}
+
 // VerifyAccessToken validates a Fly access token against the Machines API.
 func (p *FlyProvider) VerifyAccessToken(ctx context.Context, token string) error {
 	token = strings.TrimSpace(token)
@@ -544,6 +644,14 @@ func (p *FlyProvider) machinesAPIURL(path string) string {
 	return base + "/" + path
 }
 
+func (p *FlyProvider) logsAPIURL(path string) string {
+	base := strings.TrimRight(strings.TrimSpace(p.LogsAPIBaseURL), "/")
+	if base == "" {
+		base = defaultFlyLogsAPI
+	}
+	return base + path
+}
+
 func (p *FlyProvider) graphqlURL() string {
 	if strings.TrimSpace(p.GraphQLURL) != "" {
 		return strings.TrimSpace(p.GraphQLURL)
@@ -571,6 +679,15 @@ func extractFlyHost(output string) string {
 	return strings.ToLower(matches[1])
 }
 
+// WaitForRPCHealthy polls getHealth on rpcURL until it reports ok or the
+// timeout elapses.
+func WaitForRPCHealthy(ctx context.Context, rpcURL string, timeout, interval time.Duration) error {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	return waitForRPCHealthy(ctx, rpcURL, timeout, interval)
+}
+
 func waitForRPCHealthy(ctx context.Context, rpcURL string, timeout, interval time.Duration) error {
 	if strings.TrimSpace(rpcURL) == "" {
 		return errors.New("rpc URL is empty")
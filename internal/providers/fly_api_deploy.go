@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"strings"
@@ -429,6 +430,70 @@ func (p *FlyProvider) doMachinesRequest(ctx context.Context, token, method, path
 	return resp.StatusCode, body, nil
 }
 
+type flyLogsResponse struct {
+	Data []struct {
+		ID         string `json:"id"`
+		Attributes struct {
+			Timestamp string `json:"timestamp"`
+			Message   string `json:"message"`
+			Level     string `json:"level"`
+			Instance  string `json:"instance"`
+			Region    string `json:"region"`
+		} `json:"attributes"`
+	} `json:"data"`
+	Meta struct {
+		NextToken string `json:"next_token"`
+	} `json:"meta"`
+}
+
+// fetchLogs returns the next page of app logs. Passing the returned token on the
+// following call yields only lines emitted after the previous page.
+func (p *FlyProvider) fetchLogs(ctx context.Context, token, appName, nextToken string) ([]LogEntry, string, error) {
+	query := url.Values{}
+	query.Set("next_token", nextToken)
+	logsURL := p.logsAPIURL(fmt.Sprintf("/apps/%s/logs?%s", url.PathEscape(appName), query.Encode()))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, logsURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("build logs request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient().Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("logs request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 8*1024*1024))
+	if err != nil {
+		return nil, "", fmt.Errorf("read logs response: %w", err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, "", fmt.Errorf("app %q not found", appName)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, "", fmt.Errorf("logs request returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var decoded flyLogsResponse
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return nil, "", fmt.Errorf("decode logs response: %w", err)
+	}
+	entries := make([]LogEntry, 0, len(decoded.Data))
+	for _, item := range decoded.Data {
+		ts, _ := time.Parse(time.RFC3339Nano, strings.TrimSpace(item.Attributes.Timestamp))
+		entries = append(entries, LogEntry{
+			Timestamp: ts,
+			Level:     strings.TrimSpace(item.Attributes.Level),
+			Instance:  strings.TrimSpace(item.Attributes.Instance),
+			Message:   strings.TrimRight(item.Attributes.Message, "\r\n"),
+		})
+	}
+	return entries, strings.TrimSpace(decoded.Meta.NextToken), nil
+}
+
 type flyGraphQLResponse struct {
 	Data   map[string]json.RawMessage `json:"data"`
 	Errors []struct {
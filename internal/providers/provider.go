@@ -3,9 +3,13 @@ package providers
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/CharlieAIO/sol-cloud/internal/access"
+	"github.com/CharlieAIO/sol-cloud/internal/solana"
 	"github.com/CharlieAIO/sol-cloud/internal/validator"
 )
 
@@ -16,13 +20,19 @@ type Config struct {
 	Region              string
 	ProjectDir          string
 	Validator           validator.Config
+	Access              access.Config
 	DryRun              bool
 	SkipHealthCheck     bool
 	HealthCheckTimeout  time.Duration
 	HealthCheckInterval time.Duration
 	VolumeSize          int  // Volume size in GB, default 10
 	SkipVolume          bool // Skip volume creation (ephemeral storage)
-	Reporter            Reporter
+	HostPort            int  // Docker only: localhost port for the RPC; 0 picks a free one
+	// ReuseArtifacts ships the files the last deploy rendered under
+	// .sol-cloud/deployments/<name> instead of rendering them again, so
+	// Validator is ignored.
+	ReuseArtifacts bool
+	Reporter       Reporter
 }
 
 // Reporter receives high-level progress updates from providers.
@@ -39,6 +49,7 @@ type Deployment struct {
 	Provider     string
 	ArtifactsDir string
 	DashboardURL string
+	HostPort     int // Docker only
 }
 
 // Status represents high-level health for a validator deployment.
@@ -50,12 +61,36 @@ type Status struct {
 	Uptime string
 }
 
+// LogOptions controls which container log lines a provider returns.
+type LogOptions struct {
+	// Follow keeps polling for new lines until the context is cancelled.
+	Follow bool
+	// Since drops lines older than now minus this duration. Zero keeps all lines.
+	Since time.Duration
+	// Grep keeps only lines containing this text (case-insensitive).
+	Grep string
+	// OnEntry receives each matching line in timestamp order.
+	OnEntry func(LogEntry)
+}
+
+// LogEntry is a single container log line.
+type LogEntry struct {
+	Timestamp time.Time
+	Level     string
+	Instance  string
+	Message   string
+}
+
 // Provider defines deployment lifecycle operations.
 type Provider interface {
 	Deploy(ctx context.Context, cfg *Config) (*Deployment, error)
 	Destroy(ctx context.Context, name string) error
 	Status(ctx context.Context, name string) (*Status, error)
 	Restart(ctx context.Context, name string) error
+	Logs(ctx context.Context, name string, opts LogOptions) error
+	// ResetLedger asks the running validator to wipe its ledger once and
+	// restart from genesis without rebuilding or redeploying the image.
+	ResetLedger(ctx context.Context, name string) error
 }
 
 // airdropEntryTemplateData holds a single airdrop recipient for use in templates.
@@ -77,19 +112,128 @@ type validatorTemplateData struct {
 	// Legacy fields kept for backwards compatibility.
 	CloneAccounts            []string
 	CloneUpgradeablePrograms []string
+	DeactivateFeatures       []string
 	AirdropAccounts          []airdropEntryTemplateData
+	Accounts                 []accountFixtureTemplateData
 	ForceReset               bool
-	ProgramDeploy            programDeployTemplateData
+	ProgramDeploys           []programDeployTemplateData
+	Faucet                   faucetTemplateData
+}
+
+// newValidatorTemplateData copies the validator settings from cfg into
+// template data. Every provider renders the same Dockerfile, entrypoint, and
+// nginx config from it.
+func newValidatorTemplateData(cfg *Config, accountFixtures []accountFixtureTemplateData, programDeploys []programDeployTemplateData) validatorTemplateData {
+	return validatorTemplateData{
+		SlotsPerEpoch:            cfg.Validator.SlotsPerEpoch,
+		TicksPerSlot:             cfg.Validator.TicksPerSlot,
+		ComputeUnitLimit:         cfg.Validator.ComputeUnitLimit,
+		LedgerLimitSize:          cfg.Validator.LedgerLimitSize,
+		LedgerDiskLimitGB:        cfg.Validator.LedgerDiskLimitGB,
+		CloneRPCURL:              cfg.Validator.CloneRPCURL,
+		ClonePrograms:            append([]string(nil), cfg.Validator.ClonePrograms...),
+		CloneAccounts:            append([]string(nil), cfg.Validator.CloneAccounts...),
+		CloneUpgradeablePrograms: append([]string(nil), cfg.Validator.CloneUpgradeablePrograms...),
+		DeactivateFeatures:       append([]string(nil), cfg.Validator.DeactivateFeatures...),
+		AirdropAccounts:          toAirdropTemplateData(cfg.Validator.AirdropAccounts),
+		Accounts:                 accountFixtures,
+		ForceReset:               cfg.Validator.ForceReset,
+		ProgramDeploys:           programDeploys,
+		Faucet:                   toFaucetTemplateData(cfg.Validator.Faucet),
+	}
+}
+
+// faucetTemplateData configures the nginx /faucet endpoint. Amounts are in
+// lamports so the Lua handler can compare them without rounding.
+type faucetTemplateData struct {
+	Enabled       bool
+	MaxLamports   uint64
+	AddressQuota  uint64
+	IPQuota       uint64
+	WindowSeconds int64
+}
+
+// HasRuntimeProgramDeploys reports whether any program is deployed after the
+// RPC becomes healthy rather than loaded at genesis.
+func (v validatorTemplateData) HasRuntimeProgramDeploys() bool {
+	for _, program := range v.ProgramDeploys {
+		if !program.Genesis {
+			return true
+		}
+	}
+	return false
+}
+
+// accountFixtureTemplateData is a JSON account snapshot baked into the image.
+type accountFixtureTemplateData struct {
+	Address string
+	Path    string
 }
 
-// programDeployTemplateData holds startup program deploy config for templates.
+// programDeployTemplateData holds in-image paths for one startup program deploy.
+// For genesis programs the ID and authority may be pubkeys, and an empty
+// authority loads the program with --bpf-program.
 type programDeployTemplateData struct {
-	Enabled              bool
+	Genesis              bool
 	SOPath               string
 	ProgramIDKeypairPath string
 	UpgradeAuthorityPath string
 }
 
+// accessTemplateData holds the nginx access rules. HeaderVar and QueryVar
+// are the nginx variables the API key is read from.
+type accessTemplateData struct {
+	Enabled        bool
+	HeaderVar      string
+	QueryVar       string
+	Keys           []accessKeyTemplateData
+	AllowedMethods []string
+}
+
+// accessKeyTemplateData is one API key. Keys with a rate limit get their own
+// limit_req zone.
+type accessKeyTemplateData struct {
+	Name      string
+	Key       string
+	RateLimit int
+	Burst     int
+	Zone      string
+}
+
+func toAccessTemplateData(cfg access.Config) accessTemplateData {
+	cfg.ApplyDefaults()
+	data := accessTemplateData{
+		Enabled:        cfg.Enabled(),
+		HeaderVar:      "http_" + strings.ToLower(strings.ReplaceAll(cfg.Header, "-", "_")),
+		QueryVar:       "arg_" + cfg.QueryParam,
+		AllowedMethods: cfg.Methods(),
+	}
+	for i, key := range cfg.Keys {
+		data.Keys = append(data.Keys, accessKeyTemplateData{
+			Name:      key.Name,
+			Key:       key.Key,
+			RateLimit: key.RateLimit,
+			Burst:     key.RateLimit * 2,
+			Zone:      fmt.Sprintf("access_key_%d", i+1),
+		})
+	}
+	return data
+}
+
+func toFaucetTemplateData(cfg validator.FaucetConfig) faucetTemplateData {
+	if !cfg.Enabled {
+		return faucetTemplateData{}
+	}
+	window, _ := cfg.WindowDuration()
+	return faucetTemplateData{
+		Enabled:       true,
+		MaxLamports:   cfg.MaxAmount * solana.LamportsPerSOL,
+		AddressQuota:  cfg.AddressQuota * solana.LamportsPerSOL,
+		IPQuota:       cfg.IPQuota * solana.LamportsPerSOL,
+		WindowSeconds: int64(window.Seconds()),
+	}
+}
+
 // toAirdropTemplateData converts validator.AirdropEntry slice to template data.
 func toAirdropTemplateData(entries []validator.AirdropEntry) []airdropEntryTemplateData {
 	out := make([]airdropEntryTemplateData, len(entries))
@@ -106,8 +250,52 @@ func NewProvider(name string) (Provider, error) {
 		return NewFlyProvider(), nil
 	case "railway":
 		return NewRailwayProvider(), nil
+	case "docker":
+		return NewDockerProvider(), nil
 	default:
-		return nil, fmt.Errorf("unsupported provider %q: valid providers are fly, railway", name)
+		return nil, fmt.Errorf("unsupported provider %q: valid providers are fly, railway, docker", name)
+	}
+}
+
+// logFilter applies LogOptions.Since and LogOptions.Grep before entries are emitted.
+type logFilter struct {
+	cutoff time.Time
+	grep   string
+	emit   func(LogEntry)
+}
+
+func newLogFilter(opts LogOptions) *logFilter {
+	f := &logFilter{
+		grep: strings.ToLower(strings.TrimSpace(opts.Grep)),
+		emit: opts.OnEntry,
+	}
+	if opts.Since > 0 {
+		f.cutoff = time.Now().Add(-opts.Since)
+	}
+	return f
+}
+
+func (f *logFilter) Emit(entry LogEntry) {
+	if f == nil || f.emit == nil {
+		return
+	}
+	if !f.cutoff.IsZero() && !entry.Timestamp.IsZero() && entry.Timestamp.Before(f.cutoff) {
+		return
+	}
+	if f.grep != "" && !strings.Contains(strings.ToLower(entry.Message), f.grep) {
+		return
+	}
+	f.emit(entry)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
 	}
 }
 
@@ -122,3 +310,14 @@ func reportDetail(cfg *Config, message string) {
 		cfg.Reporter.Detail(message)
 	}
 }
+
+// checkRenderedArtifacts fails unless every named file from a previous deploy
+// is present in artifactsDir.
+func checkRenderedArtifacts(artifactsDir string, names ...string) error {
+	for _, name := range names {
+		if _, err := os.Stat(filepath.Join(artifactsDir, name)); err != nil {
+			return fmt.Errorf("no rendered %s in %s; run `sol-cloud deploy` first: %w", name, artifactsDir, err)
+		}
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -28,7 +29,9 @@ type railwayDeploymentIDs struct {
 
 // railwayTemplateData is the template data passed to provider-agnostic templates for Railway.
 type railwayTemplateData struct {
-	Validator validatorTemplateData
+	Validator   validatorTemplateData
+	Access      accessTemplateData
+	ClientIPVar string
 }
 
 // RailwayProvider manages validator deployments on Railway.
@@ -68,49 +71,14 @@ func (p *RailwayProvider) Deploy(ctx context.Context, cfg *Config) (*Deployment,
 	if err := os.MkdirAll(artifactsDir, 0o755); err != nil {
 		return nil, fmt.Errorf("create artifacts directory: %w", err)
 	}
-	programDir := filepath.Join(artifactsDir, "program")
-	if err := os.MkdirAll(programDir, 0o755); err != nil {
-		return nil, fmt.Errorf("create program artifacts directory: %w", err)
-	}
-
-	programDeployData, err := prepareProgramDeployData(projectDir, programDir, cfg)
-	if err != nil {
-		return nil, err
-	}
-
-	data := railwayTemplateData{
-		Validator: validatorTemplateData{
-			SlotsPerEpoch:            cfg.Validator.SlotsPerEpoch,
-			TicksPerSlot:             cfg.Validator.TicksPerSlot,
-			ComputeUnitLimit:         cfg.Validator.ComputeUnitLimit,
-			LedgerLimitSize:          cfg.Validator.LedgerLimitSize,
-			LedgerDiskLimitGB:        cfg.Validator.LedgerDiskLimitGB,
-			CloneRPCURL:              cfg.Validator.CloneRPCURL,
-			ClonePrograms:            append([]string(nil), cfg.Validator.ClonePrograms...),
-			CloneAccounts:            append([]string(nil), cfg.Validator.CloneAccounts...),
-			CloneUpgradeablePrograms: append([]string(nil), cfg.Validator.CloneUpgradeablePrograms...),
-			AirdropAccounts:          toAirdropTemplateData(cfg.Validator.AirdropAccounts),
-			ForceReset:               cfg.Validator.ForceReset,
-			ProgramDeploy:            programDeployData,
-		},
-	}
-
-	// Railway does not need fly.toml — it auto-detects the Dockerfile.
-	templateTargets := []struct {
-		Name string
-		Dst  string
-	}{
-		{Name: "Dockerfile.tmpl", Dst: filepath.Join(artifactsDir, "Dockerfile")},
-		{Name: "nginx.conf.tmpl", Dst: filepath.Join(artifactsDir, "nginx.conf")},
-		{Name: "entrypoint.sh.tmpl", Dst: filepath.Join(artifactsDir, "entrypoint.sh")},
-	}
-	for _, target := range templateTargets {
-		reportDetail(cfg, "Rendering "+filepath.Base(target.Dst))
-		if err := renderEmbeddedTemplateFile(target.Name, target.Dst, data); err != nil {
+	if cfg.ReuseArtifacts {
+		if err := checkRenderedArtifacts(artifactsDir, "Dockerfile", "nginx.conf", "entrypoint.sh"); err != nil {
 			return nil, err
 		}
+		reportStep(cfg, "Reusing rendered artifacts")
+	} else if err := p.renderArtifacts(cfg, projectDir, artifactsDir); err != nil {
+		return nil, err
 	}
-	reportStep(cfg, "Rendered provider templates")
 
 	deployment := &Deployment{
 		Name:         cfg.Name,
@@ -178,7 +146,7 @@ func (p *RailwayProvider) Deploy(ctx context.Context, cfg *Config) (*Deployment,
 			interval = defaultPollInterval
 		}
 		reportStep(cfg, "Waiting for RPC health")
-		if err := waitForRPCHealthy(ctx, deployment.RPCURL, timeout, interval); err != nil {
+		if err := waitForRPCHealthy(ctx, cfg.Access.AuthorizeURL(deployment.RPCURL), timeout, interval); err != nil {
 			return nil, fmt.Errorf("deployment completed but RPC health check failed: %w", err)
 		}
 	} else {
@@ -188,6 +156,49 @@ func (p *RailwayProvider) Deploy(ctx context.Context, cfg *Config) (*Deployment,
 	return deployment, nil
 }
 
+// renderArtifacts stages program and account fixtures and renders the
+// provider templates into artifactsDir.
+func (p *RailwayProvider) renderArtifacts(cfg *Config, projectDir, artifactsDir string) error {
+	programDir := filepath.Join(artifactsDir, "program")
+	if err := os.MkdirAll(programDir, 0o755); err != nil {
+		return fmt.Errorf("create program artifacts directory: %w", err)
+	}
+
+	programDeployData, err := prepareProgramDeployData(projectDir, programDir, cfg)
+	if err != nil {
+		return err
+	}
+	accountFixtures, err := prepareAccountFixtureData(projectDir, filepath.Join(artifactsDir, "accounts"), cfg)
+	if err != nil {
+		return err
+	}
+
+	data := railwayTemplateData{
+		Access: toAccessTemplateData(cfg.Access),
+		// Railway's edge sets X-Real-IP to the connecting client.
+		ClientIPVar: "http_x_real_ip",
+		Validator:   newValidatorTemplateData(cfg, accountFixtures, programDeployData),
+	}
+
+	// Railway does not need fly.toml — it auto-detects the Dockerfile.
+	templateTargets := []struct {
+		Name string
+		Dst  string
+	}{
+		{Name: "Dockerfile.tmpl", Dst: filepath.Join(artifactsDir, "Dockerfile")},
+		{Name: "nginx.conf.tmpl", Dst: filepath.Join(artifactsDir, "nginx.conf")},
+		{Name: "entrypoint.sh.tmpl", Dst: filepath.Join(artifactsDir, "entrypoint.sh")},
+	}
+	for _, target := range templateTargets {
+		reportDetail(cfg, "Rendering "+filepath.Base(target.Dst))
+		if err := renderEmbeddedTemplateFile(target.Name, target.Dst, data); err != nil {
+			return err
+		}
+	}
+	reportStep(cfg, "Rendered provider templates")
+	return nil
+}
+
 func (p *RailwayProvider) Destroy(ctx context.Context, name string) error {
 	if strings.TrimSpace(name) == "" {
 		return errors.New("deployment name is required")
@@ -252,6 +263,99 @@ func (p *RailwayProvider) Restart(ctx context.Context, name string) error {
 	return nil
 }
 
+// ResetLedger sets a new SOL_CLOUD_RESET_TOKEN service variable and redeploys
+// the existing image. Railway cannot signal a running container, so the
+// entrypoint wipes the ledger once when it sees a token it has not recorded.
+func (p *RailwayProvider) ResetLedger(ctx context.Context, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("deployment name is required")
+	}
+
+	token, err := p.resolveAccessToken()
+	if err != nil {
+		return fmt.Errorf("railway auth required: run `sol-cloud auth railway`: %w", err)
+	}
+
+	ids, err := p.loadIDs(name)
+	if err != nil {
+		return fmt.Errorf("load railway ids: %w", err)
+	}
+
+	client := p.httpClient()
+	resetToken := strconv.FormatInt(time.Now().UnixNano(), 10)
+	if err := upsertRailwayVariable(ctx, client, p.graphqlURL(), token, ids.ProjectID, ids.ServiceID, "SOL_CLOUD_RESET_TOKEN", resetToken); err != nil {
+		return fmt.Errorf("set reset token: %w", err)
+	}
+	if err := restartRailwayService(ctx, client, p.graphqlURL(), token, ids.ProjectID, ids.ServiceID); err != nil {
+		return fmt.Errorf("restart railway service: %w", err)
+	}
+	return nil
+}
+
+func (p *RailwayProvider) Logs(ctx context.Context, name string, opts LogOptions) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("deployment name is required")
+	}
+
+	token, err := p.resolveAccessToken()
+	if err != nil {
+		return fmt.Errorf("railway auth required: run `sol-cloud auth railway`: %w", err)
+	}
+
+	ids, err := p.loadIDs(name)
+	if err != nil {
+		return fmt.Errorf("load railway ids: %w", err)
+	}
+
+	deploymentID, err := latestRailwayDeploymentID(ctx, p.httpClient(), p.graphqlURL(), token, ids.ProjectID, ids.ServiceID)
+	if err != nil {
+		return err
+	}
+
+	filter := newLogFilter(opts)
+	var since time.Time
+	if opts.Since > 0 {
+		since = time.Now().Add(-opts.Since)
+	}
+	// Railway returns overlapping windows when polling by start date, so the
+	// last emitted timestamp and the lines printed at that instant are tracked.
+	var lastSeen time.Time
+	seenAtLast := map[string]struct{}{}
+	for {
+		entries, err := fetchRailwayDeploymentLogs(ctx, p.httpClient(), p.graphqlURL(), token, deploymentID, since)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		for _, entry := range entries {
+			if entry.Timestamp.Before(lastSeen) {
+				continue
+			}
+			if entry.Timestamp.Equal(lastSeen) {
+				if _, ok := seenAtLast[entry.Message]; ok {
+					continue
+				}
+			} else {
+				lastSeen = entry.Timestamp
+				seenAtLast = map[string]struct{}{}
+			}
+			seenAtLast[entry.Message] = struct{}{}
+			filter.Emit(entry)
+		}
+		if !opts.Follow {
+			return nil
+		}
+		if !lastSeen.IsZero() {
+			since = lastSeen
+		}
+		if err := sleepContext(ctx, defaultLogsPoll); err != nil {
+			return nil
+		}
+	}
+}
+
 // ListWorkspaces returns all Railway workspaces accessible with the given token.
 func (p *RailwayProvider) ListWorkspaces(ctx context.Context, token string) ([]RailwayWorkspace, error) {
 	return ListRailwayWorkspaces(ctx, p.httpClient(), p.graphqlURL(), token)
@@ -11,6 +11,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	appconfig "github.com/CharlieAIO/sol-cloud/internal/config"
 )
@@ -747,6 +748,93 @@ func getRailwayServiceStatus(ctx context.Context, client *http.Client, graphqlUR
 	}
 }
 
+// latestRailwayDeploymentID returns the most recent deployment for a service.
+func latestRailwayDeploymentID(ctx context.Context, client *http.Client, graphqlURL, token, projectID, serviceID string) (string, error) {
+	query := `query Deployments($input: DeploymentListInput!) {
+		deployments(input: $input, first: 1) {
+			edges {
+				node {
+					id
+				}
+			}
+		}
+	}`
+	resp, err := railwayGraphQLRequest(ctx, client, graphqlURL, token, query, map[string]any{
+		"input": map[string]any{
+			"projectId": projectID,
+			"serviceId": serviceID,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Errors) > 0 {
+		return "", fmt.Errorf("list railway deployments error: %s", resp.Errors[0].Message)
+	}
+
+	var deploymentsResult struct {
+		Edges []struct {
+			Node struct {
+				ID string `json:"id"`
+			} `json:"node"`
+		} `json:"edges"`
+	}
+	if raw, ok := resp.Data["deployments"]; ok {
+		_ = json.Unmarshal(raw, &deploymentsResult)
+	}
+	if len(deploymentsResult.Edges) == 0 || strings.TrimSpace(deploymentsResult.Edges[0].Node.ID) == "" {
+		return "", fmt.Errorf("no railway deployments found for service %s", serviceID)
+	}
+	return deploymentsResult.Edges[0].Node.ID, nil
+}
+
+// fetchRailwayDeploymentLogs returns runtime logs for a deployment, optionally
+// starting at a timestamp.
+func fetchRailwayDeploymentLogs(ctx context.Context, client *http.Client, graphqlURL, token, deploymentID string, since time.Time) ([]LogEntry, error) {
+	query := `query DeploymentLogs($deploymentId: String!, $limit: Int, $startDate: DateTime) {
+		deploymentLogs(deploymentId: $deploymentId, limit: $limit, startDate: $startDate) {
+			message
+			timestamp
+			severity
+		}
+	}`
+	variables := map[string]any{
+		"deploymentId": deploymentID,
+		"limit":        500,
+	}
+	if !since.IsZero() {
+		variables["startDate"] = since.UTC().Format(time.RFC3339Nano)
+	}
+	resp, err := railwayGraphQLRequest(ctx, client, graphqlURL, token, query, variables)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Errors) > 0 {
+		return nil, fmt.Errorf("get railway deployment logs error: %s", resp.Errors[0].Message)
+	}
+
+	var lines []struct {
+		Message   string `json:"message"`
+		Timestamp string `json:"timestamp"`
+		Severity  string `json:"severity"`
+	}
+	if raw, ok := resp.Data["deploymentLogs"]; ok {
+		if err := json.Unmarshal(raw, &lines); err != nil {
+			return nil, fmt.Errorf("decode railway deployment logs: %w", err)
+		}
+	}
+	entries := make([]LogEntry, 0, len(lines))
+	for _, line := range lines {
+		ts, _ := time.Parse(time.RFC3339Nano, strings.TrimSpace(line.Timestamp))
+		entries = append(entries, LogEntry{
+			Timestamp: ts,
+			Level:     strings.ToLower(strings.TrimSpace(line.Severity)),
+			Message:   strings.TrimRight(line.Message, "\r\n"),
+		})
+	}
+	return entries, nil
+}
+
 func restartRailwayService(ctx context.Context, client *http.Client, graphqlURL, token, projectID, serviceID string) error {
 	// Railway uses serviceInstanceRedeploy which requires environmentId.
 	// First get the environment ID.
@@ -770,3 +858,31 @@ func restartRailwayService(ctx context.Context, client *http.Client, graphqlURL,
 	}
 	return nil
 }
+
+// upsertRailwayVariable sets a service variable in the project's default environment.
+func upsertRailwayVariable(ctx context.Context, client *http.Client, graphqlURL, token, projectID, serviceID, name, value string) error {
+	envID, err := resolveRailwayEnvironmentID(ctx, client, graphqlURL, token, projectID)
+	if err != nil {
+		return fmt.Errorf("resolve environment for variable: %w", err)
+	}
+
+	mutation := `mutation VariableUpsert($input: VariableUpsertInput!) {
+		variableUpsert(input: $input)
+	}`
+	resp, err := railwayGraphQLRequest(ctx, client, graphqlURL, token, mutation, map[string]any{
+		"input": map[string]any{
+			"projectId":     projectID,
+			"environmentId": envID,
+			"serviceId":     serviceID,
+			"name":          name,
+			"value":         value,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if len(resp.Errors) > 0 {
+		return fmt.Errorf("upsert railway variable error: %s", resp.Errors[0].Message)
+	}
+	return nil
+}
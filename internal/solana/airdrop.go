@@ -0,0 +1,74 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LamportsPerSOL is the number of lamports in one SOL.
+const LamportsPerSOL uint64 = 1_000_000_000
+
+// FormatSOL renders lamports as a SOL amount without trailing zeros.
+func FormatSOL(lamports uint64) string {
+	whole := strconv.FormatUint(lamports/LamportsPerSOL, 10)
+	frac := lamports % LamportsPerSOL
+	if frac == 0 {
+		return whole
+	}
+	return whole + "." + strings.TrimRight(fmt.Sprintf("%09d", frac), "0")
+}
+
+// RequestAirdrop asks the validator's faucet to send lamports to address and
+// returns the transaction signature.
+func (c *Client) RequestAirdrop(ctx context.Context, address string, lamports uint64) (string, error) {
+	var signature string
+	if err := c.call(ctx, "requestAirdrop", []any{address, lamports}, &signature); err != nil {
+		return "", err
+	}
+	return signature, nil
+}
+
+// maxSignatureStatuses is the getSignatureStatuses request limit.
+const maxSignatureStatuses = 256
+
+// SignatureStatus is a getSignatureStatuses entry. Err holds the transaction
+// error as returned by the RPC, or nil when the transaction succeeded.
+type SignatureStatus struct {
+	Slot               uint64          `json:"slot"`
+	Err                json.RawMessage `json:"err"`
+	ConfirmationStatus string          `json:"confirmationStatus"`
+}
+
+// Failed reports whether the transaction landed with an error.
+func (s *SignatureStatus) Failed() bool {
+	return len(s.Err) > 0 && string(s.Err) != "null"
+}
+
+// Confirmed reports whether the transaction reached confirmed or finalized
+// commitment.
+func (s *SignatureStatus) Confirmed() bool {
+	return s.ConfirmationStatus == "confirmed" || s.ConfirmationStatus == "finalized"
+}
+
+// GetSignatureStatuses returns the status of each signature, in order. Entries
+// are nil for signatures the validator has not seen.
+func (c *Client) GetSignatureStatuses(ctx context.Context, signatures []string) ([]*SignatureStatus, error) {
+	statuses := make([]*SignatureStatus, 0, len(signatures))
+	for start := 0; start < len(signatures); start += maxSignatureStatuses {
+		end := min(start+maxSignatureStatuses, len(signatures))
+		var result struct {
+			Value []*SignatureStatus `json:"value"`
+		}
+		if err := c.call(ctx, "getSignatureStatuses", []any{signatures[start:end]}, &result); err != nil {
+			return nil, err
+		}
+		if len(result.Value) != end-start {
+			return nil, fmt.Errorf("getSignatureStatuses returned %d statuses for %d signatures", len(result.Value), end-start)
+		}
+		statuses = append(statuses, result.Value...)
+	}
+	return statuses, nil
+}
@@ -0,0 +1,163 @@
+// Package solana holds the small amount of Solana address and account encoding
+// sol-cloud needs without shelling out to the Solana CLI.
+package solana
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+// PublicKey is a 32-byte Solana address.
+type PublicKey [32]byte
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// String returns the base58 form of the key.
+func (k PublicKey) String() string {
+	return EncodeBase58(k[:])
+}
+
+// ParsePublicKey decodes a base58 address.
+func ParsePublicKey(value string) (PublicKey, error) {
+	var key PublicKey
+	data, err := DecodeBase58(value)
+	if err != nil {
+		return key, err
+	}
+	if len(data) != len(key) {
+		return key, fmt.Errorf("address %q decodes to %d bytes, want 32", value, len(data))
+	}
+	copy(key[:], data)
+	return key, nil
+}
+
+// ReadKeypairPublicKey reads a Solana CLI keypair file (a JSON array of 64
+// bytes) and returns its public key.
+func ReadKeypairPublicKey(path string) (PublicKey, error) {
+	var key PublicKey
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return key, err
+	}
+	var keypair []int
+	if err := json.Unmarshal(data, &keypair); err != nil || len(keypair) != 64 {
+		return key, fmt.Errorf("%s is not a 64-byte keypair file", path)
+	}
+	for i, n := range keypair[32:] {
+		key[i] = byte(n)
+	}
+	return key, nil
+}
+
+// EncodeBase58 encodes data with the Bitcoin alphabet used for Solana addresses.
+func EncodeBase58(data []byte) string {
+	n := new(big.Int).SetBytes(data)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		out = append(out, base58Alphabet[0])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// DecodeBase58 decodes a base58 string.
+func DecodeBase58(value string) ([]byte, error) {
+	if value == "" {
+		return nil, errors.New("empty base58 string")
+	}
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	zeros := 0
+	for i := 0; i < len(value) && value[i] == base58Alphabet[0]; i++ {
+		zeros++
+	}
+	for i := 0; i < len(value); i++ {
+		digit := -1
+		for j := 0; j < len(base58Alphabet); j++ {
+			if base58Alphabet[j] == value[i] {
+				digit = j
+				break
+			}
+		}
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", value[i])
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
+
+// FindProgramAddress derives the program address and bump seed for seeds, the
+// same way as Pubkey::find_program_address.
+func FindProgramAddress(seeds [][]byte, programID PublicKey) (PublicKey, uint8, error) {
+	for bump := 255; bump >= 0; bump-- {
+		h := sha256.New()
+		for _, seed := range seeds {
+			h.Write(seed)
+		}
+		h.Write([]byte{byte(bump)})
+		h.Write(programID[:])
+		h.Write([]byte("ProgramDerivedAddress"))
+		var candidate PublicKey
+		copy(candidate[:], h.Sum(nil))
+		if !isOnCurve(candidate) {
+			return candidate, uint8(bump), nil
+		}
+	}
+	return PublicKey{}, 0, errors.New("unable to find a viable program address bump seed")
+}
+
+var (
+	curveP = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+	// curveD is -121665/121666 mod p.
+	curveD = func() *big.Int {
+		d := new(big.Int).ModInverse(big.NewInt(121666), curveP)
+		d.Mul(d, big.NewInt(-121665))
+		return d.Mod(d, curveP)
+	}()
+	curveLegendreExp = new(big.Int).Rsh(new(big.Int).Sub(curveP, big.NewInt(1)), 1)
+)
+
+// isOnCurve reports whether key decompresses to an ed25519 point: x² =
+// (y²-1)/(d·y²+1) must have a square root mod p.
+func isOnCurve(key PublicKey) bool {
+	le := key
+	le[31] &= 0x7f
+	for i, j := 0, len(le)-1; i < j; i, j = i+1, j-1 {
+		le[i], le[j] = le[j], le[i]
+	}
+	y := new(big.Int).SetBytes(le[:])
+	y.Mod(y, curveP)
+
+	y2 := new(big.Int).Mul(y, y)
+	y2.Mod(y2, curveP)
+	u := new(big.Int).Sub(y2, big.NewInt(1))
+	u.Mod(u, curveP)
+	if u.Sign() == 0 {
+		return true
+	}
+	v := new(big.Int).Mul(curveD, y2)
+	v.Add(v, big.NewInt(1))
+	v.Mod(v, curveP)
+
+	x2 := new(big.Int).ModInverse(v, curveP)
+	x2.Mul(x2, u)
+	x2.Mod(x2, curveP)
+	return new(big.Int).Exp(x2, curveLegendreExp, curveP).Cmp(big.NewInt(1)) == 0
+}
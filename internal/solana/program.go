@@ -0,0 +1,125 @@
+package solana
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+)
+
+// BPF loader program IDs.
+var (
+	BPFLoaderDeprecatedProgramID = mustPublicKey("BPFLoader1111111111111111111111111111111111")
+	BPFLoaderProgramID           = mustPublicKey("BPFLoader2111111111111111111111111111111111")
+	BPFLoaderUpgradeableID       = mustPublicKey("BPFLoaderUpgradeab1e11111111111111111111111")
+)
+
+// UpgradeableLoaderState variants and the bincode sizes of their headers.
+const (
+	upgradeableStateBuffer      = 1
+	upgradeableStateProgram     = 2
+	upgradeableStateProgramData = 3
+
+	upgradeableBufferMetadataSize      = 37 // tag, Option<authority>
+	upgradeableProgramSize             = 36 // tag, programdata address
+	upgradeableProgramDataMetadataSize = 45 // tag, slot, Option<authority>
+)
+
+func upgradeableState(data []byte) uint32 {
+	if len(data) < 4 {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(data[0:4])
+}
+
+// ProgramDataAddress returns the programdata account of an upgradeable
+// program, or "" when programID is not an upgradeable program.
+func (c *Client) ProgramDataAddress(ctx context.Context, programID string) (string, error) {
+	account, err := c.GetAccountInfo(ctx, programID)
+	if err != nil {
+		return "", err
+	}
+	return account.ProgramDataAddress(), nil
+}
+
+// ProgramDataAddress returns the programdata account referenced by an
+// upgradeable program account, or "" for any other account.
+func (a *Account) ProgramDataAddress() string {
+	if a == nil || a.Owner != BPFLoaderUpgradeableID.String() ||
+		len(a.Data) < upgradeableProgramSize || upgradeableState(a.Data) != upgradeableStateProgram {
+		return ""
+	}
+	var address PublicKey
+	copy(address[:], a.Data[4:upgradeableProgramSize])
+	return address.String()
+}
+
+// ProgramDataCapacity returns how many bytes of program binary an upgradeable
+// programdata account can hold, or -1 for any other account.
+func (a *Account) ProgramDataCapacity() int {
+	if a == nil || a.Owner != BPFLoaderUpgradeableID.String() ||
+		len(a.Data) < upgradeableProgramDataMetadataSize || upgradeableState(a.Data) != upgradeableStateProgramData {
+		return -1
+	}
+	return len(a.Data) - upgradeableProgramDataMetadataSize
+}
+
+// ResetProgramDataSlot returns account with the deployment slot of an
+// upgradeable programdata account set to 0. A validator that loads the
+// account at genesis would otherwise see the program as deployed in a future
+// slot and refuse to invoke it. Any other account is returned unchanged.
+func ResetProgramDataSlot(account Account) Account {
+	if account.Owner != BPFLoaderUpgradeableID.String() ||
+		len(account.Data) < upgradeableProgramDataMetadataSize || upgradeableState(account.Data) != upgradeableStateProgramData {
+		return account
+	}
+	data := append([]byte(nil), account.Data...)
+	binary.LittleEndian.PutUint64(data[4:12], 0)
+	account.Data = data
+	return account
+}
+
+// DumpProgram returns the program binary stored at address, matching
+// `solana program dump`: the account data for the non-upgradeable loaders,
+// the programdata contents after its header for upgradeable programs, and the
+// buffer contents after its header for upgradeable loader buffers.
+func (c *Client) DumpProgram(ctx context.Context, address string) ([]byte, error) {
+	account, err := c.GetAccountInfo(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, fmt.Errorf("unable to find the account %s", address)
+	}
+
+	switch account.Owner {
+	case BPFLoaderProgramID.String(), BPFLoaderDeprecatedProgramID.String():
+		return account.Data, nil
+	case BPFLoaderUpgradeableID.String():
+	default:
+		return nil, fmt.Errorf("%s is not an SBF program", address)
+	}
+
+	switch upgradeableState(account.Data) {
+	case upgradeableStateProgram:
+		programData := account.ProgramDataAddress()
+		if programData == "" {
+			return nil, fmt.Errorf("program %s has been closed", address)
+		}
+		dataAccount, err := c.GetAccountInfo(ctx, programData)
+		if err != nil {
+			return nil, err
+		}
+		if dataAccount == nil || len(dataAccount.Data) < upgradeableProgramDataMetadataSize ||
+			upgradeableState(dataAccount.Data) != upgradeableStateProgramData {
+			return nil, fmt.Errorf("program %s has been closed", address)
+		}
+		return dataAccount.Data[upgradeableProgramDataMetadataSize:], nil
+	case upgradeableStateBuffer:
+		if len(account.Data) < upgradeableBufferMetadataSize {
+			return nil, fmt.Errorf("buffer %s is truncated", address)
+		}
+		return account.Data[upgradeableBufferMetadataSize:], nil
+	default:
+		return nil, fmt.Errorf("%s is not an upgradeable loader buffer or program account", address)
+	}
+}
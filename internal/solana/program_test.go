@@ -0,0 +1,74 @@
+package solana
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+func TestDumpProgramMatchesCLI(t *testing.T) {
+	const (
+		programID   = "Prog111111111111111111111111111111111111111"
+		programData = "ProgData11111111111111111111111111111111111"
+		buffer      = "Buffer1111111111111111111111111111111111111"
+		legacy      = "Legacy1111111111111111111111111111111111111"
+		deprecated  = "Deprecated111111111111111111111111111111111"
+		closed      = "C1osed11111111111111111111111111111111111111"
+		system      = "System1111111111111111111111111111111111111"
+	)
+	// The ELF payload keeps its trailing zeros: `solana program dump` writes
+	// everything after the loader header, including unused capacity.
+	elf := append([]byte("\x7fELF\x02\x01\x01\x00sbf-program"), make([]byte, 16)...)
+	authority := bytes.Repeat([]byte{0xAA}, 32)
+	programDataKey, err := ParsePublicKey(programData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tag := func(state uint32) []byte {
+		return binary.LittleEndian.AppendUint32(nil, state)
+	}
+	// Program: tag, programdata address.
+	programAccount := append(tag(upgradeableStateProgram), programDataKey[:]...)
+	// ProgramData: tag, slot, Some(authority), ELF.
+	programDataAccount := append(tag(upgradeableStateProgramData), binary.LittleEndian.AppendUint64(nil, 123456)...)
+	programDataAccount = append(append(append(programDataAccount, 1), authority...), elf...)
+	// Buffer: tag, Some(authority), ELF.
+	bufferAccount := append(append(append(tag(upgradeableStateBuffer), 1), authority...), elf...)
+	// Program whose programdata account was closed.
+	closedAccount := append(tag(upgradeableStateProgram), bytes.Repeat([]byte{0x01}, 32)...)
+
+	upgradeable := BPFLoaderUpgradeableID.String()
+	client := newTestRPC(t, map[string]Account{
+		programID:   {Owner: upgradeable, Data: programAccount, Executable: true},
+		programData: {Owner: upgradeable, Data: programDataAccount},
+		buffer:      {Owner: upgradeable, Data: bufferAccount},
+		legacy:      {Owner: BPFLoaderProgramID.String(), Data: elf, Executable: true},
+		deprecated:  {Owner: BPFLoaderDeprecatedProgramID.String(), Data: elf, Executable: true},
+		closed:      {Owner: upgradeable, Data: closedAccount, Executable: true},
+		system:      {Owner: "11111111111111111111111111111111", Data: nil},
+	})
+
+	for _, address := range []string{programID, buffer, legacy, deprecated} {
+		got, err := client.DumpProgram(context.Background(), address)
+		if err != nil {
+			t.Fatalf("DumpProgram(%s): %v", address, err)
+		}
+		if !bytes.Equal(got, elf) {
+			t.Fatalf("DumpProgram(%s) = %q, want %q", address, got, elf)
+		}
+	}
+
+	for address, want := range map[string]string{
+		closed: "has been closed",
+		system: "is not an SBF program",
+		"Missing111111111111111111111111111111111111": "unable to find the account",
+	} {
+		_, err := client.DumpProgram(context.Background(), address)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("DumpProgram(%s) error = %v, want %q", address, err, want)
+		}
+	}
+}
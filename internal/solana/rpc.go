@@ -0,0 +1,336 @@
+package solana
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxMultipleAccounts is the getMultipleAccounts request limit.
+const maxMultipleAccounts = 100
+
+// Client is a minimal Solana JSON-RPC client. Reads use the confirmed
+// commitment, the Solana CLI default.
+type Client struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+// NewClient returns a Client for rpcURL using http.DefaultClient.
+func NewClient(rpcURL string) *Client {
+	return &Client{URL: strings.TrimSpace(rpcURL), HTTPClient: http.DefaultClient}
+}
+
+// Account is an account read over RPC, with its data decoded.
+type Account struct {
+	Lamports   uint64
+	Data       []byte
+	Owner      string
+	Executable bool
+	RentEpoch  uint64
+	Space      int
+}
+
+// rpcAccount is the base64-encoded account shape returned by the RPC.
+type rpcAccount struct {
+	Lamports   uint64    `json:"lamports"`
+	Data       [2]string `json:"data"`
+	Owner      string    `json:"owner"`
+	Executable bool      `json:"executable"`
+	RentEpoch  uint64    `json:"rentEpoch"`
+	Space      int       `json:"space"`
+}
+
+func (a *rpcAccount) decode() (*Account, error) {
+	if a == nil {
+		return nil, nil
+	}
+	if a.Data[1] != "base64" {
+		return nil, fmt.Errorf("unexpected account data encoding %q", a.Data[1])
+	}
+	data, err := base64.StdEncoding.DecodeString(a.Data[0])
+	if err != nil {
+		return nil, fmt.Errorf("decode account data: %w", err)
+	}
+	return &Account{
+		Lamports:   a.Lamports,
+		Data:       data,
+		Owner:      a.Owner,
+		Executable: a.Executable,
+		RentEpoch:  a.RentEpoch,
+		Space:      a.Space,
+	}, nil
+}
+
+var accountConfig = map[string]string{"encoding": "base64", "commitment": "confirmed"}
+
+// GetAccountInfo returns the account at address, or nil when it does not exist.
+func (c *Client) GetAccountInfo(ctx context.Context, address string) (*Account, error) {
+	var result struct {
+		Value *rpcAccount `json:"value"`
+	}
+	if err := c.call(ctx, "getAccountInfo", []any{address, accountConfig}, &result); err != nil {
+		return nil, err
+	}
+	return result.Value.decode()
+}
+
+// GetMultipleAccounts returns the accounts at addresses in order, with nil for
+// accounts that do not exist. Requests are batched at the RPC limit.
+func (c *Client) GetMultipleAccounts(ctx context.Context, addresses []string) ([]*Account, error) {
+	accounts, _, err := c.GetMultipleAccountsWithSlot(ctx, addresses)
+	return accounts, err
+}
+
+// GetMultipleAccountsWithSlot is GetMultipleAccounts that also returns the
+// lowest context slot the accounts were read at.
+func (c *Client) GetMultipleAccountsWithSlot(ctx context.Context, addresses []string) ([]*Account, uint64, error) {
+	accounts := make([]*Account, 0, len(addresses))
+	var slot uint64
+	for start := 0; start < len(addresses); start += maxMultipleAccounts {
+		batch := addresses[start:min(start+maxMultipleAccounts, len(addresses))]
+		var result struct {
+			Context struct {
+				Slot uint64 `json:"slot"`
+			} `json:"context"`
+			Value []*rpcAccount `json:"value"`
+		}
+		if err := c.call(ctx, "getMultipleAccounts", []any{batch, accountConfig}, &result); err != nil {
+			return nil, 0, err
+		}
+		if len(result.Value) != len(batch) {
+			return nil, 0, fmt.Errorf("getMultipleAccounts returned %d accounts for %d addresses", len(result.Value), len(batch))
+		}
+		if slot == 0 || result.Context.Slot < slot {
+			slot = result.Context.Slot
+		}
+		for i, raw := range result.Value {
+			account, err := raw.decode()
+			if err != nil {
+				return nil, 0, fmt.Errorf("account %s: %w", batch[i], err)
+			}
+			accounts = append(accounts, account)
+		}
+	}
+	return accounts, slot, nil
+}
+
+// FetchAccountSnapshots reads addresses from rpcURL and returns each account
+// that exists as an --account snapshot, keyed by address.
+func FetchAccountSnapshots(ctx context.Context, rpcURL string, addresses []string) (map[string][]byte, error) {
+	accounts, err := NewClient(rpcURL).GetMultipleAccounts(ctx, addresses)
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make(map[string][]byte, len(addresses))
+	for i, account := range accounts {
+		if account == nil {
+			continue
+		}
+		data, err := AccountSnapshotJSON(addresses[i], *account)
+		if err != nil {
+			return nil, err
+		}
+		snapshots[addresses[i]] = data
+	}
+	return snapshots, nil
+}
+
+// AccountSnapshotJSON renders account as `solana account --output json-compact`
+// does, byte for byte, so it can be loaded with --account.
+func AccountSnapshotJSON(address string, account Account) ([]byte, error) {
+	var doc accountJSON
+	doc.Pubkey = address
+	doc.Account.Lamports = account.Lamports
+	doc.Account.Data = [2]string{base64.StdEncoding.EncodeToString(account.Data), "base64"}
+	doc.Account.Owner = account.Owner
+	doc.Account.Executable = account.Executable
+	doc.Account.RentEpoch = account.RentEpoch
+	doc.Account.Space = account.Space
+	return json.Marshal(doc)
+}
+
+// ParseAccountSnapshot decodes an --account snapshot and returns its address
+// and account.
+func ParseAccountSnapshot(snapshot []byte) (string, Account, error) {
+	var doc accountJSON
+	if err := json.Unmarshal(snapshot, &doc); err != nil {
+		return "", Account{}, fmt.Errorf("decode account snapshot: %w", err)
+	}
+	raw := rpcAccount(doc.Account)
+	account, err := raw.decode()
+	if err != nil {
+		return "", Account{}, err
+	}
+	return doc.Pubkey, *account, nil
+}
+
+func (c *Client) call(ctx context.Context, method string, params any, result any) error {
+	if c.URL == "" {
+		return fmt.Errorf("%s: rpc url is required", method)
+	}
+	body, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal rpc request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create rpc request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", method, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+		return fmt.Errorf("%s: rpc status %d: %s", method, resp.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+
+	var decoded struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return fmt.Errorf("decode %s response: %w", method, err)
+	}
+	if decoded.Error != nil {
+		return fmt.Errorf("%s: rpc error %d: %s", method, decoded.Error.Code, decoded.Error.Message)
+	}
+	if err := json.Unmarshal(decoded.Result, result); err != nil {
+		return fmt.Errorf("decode %s result: %w", method, err)
+	}
+	return nil
+}
+
+// KeyedAccount is an account returned together with its address.
+type KeyedAccount struct {
+	Address string
+	Account Account
+}
+
+// MemcmpFilter matches accounts whose data at Offset equals the base58 Bytes.
+type MemcmpFilter struct {
+	Offset uint64
+	Bytes  string
+}
+
+// maxMemcmpBytes is the RPC limit on decoded memcmp filter data.
+const maxMemcmpBytes = 128
+
+// ParseMemcmpFilter parses an "offset:base58" filter.
+func ParseMemcmpFilter(value string) (MemcmpFilter, error) {
+	offset, data, ok := strings.Cut(strings.TrimSpace(value), ":")
+	if !ok {
+		return MemcmpFilter{}, fmt.Errorf("memcmp filter %q must be offset:base58", value)
+	}
+	parsedOffset, err := strconv.ParseUint(offset, 10, 64)
+	if err != nil {
+		return MemcmpFilter{}, fmt.Errorf("memcmp filter %q has invalid offset", value)
+	}
+	decoded, err := DecodeBase58(data)
+	if err != nil {
+		return MemcmpFilter{}, fmt.Errorf("memcmp filter %q: %w", value, err)
+	}
+	if len(decoded) > maxMemcmpBytes {
+		return MemcmpFilter{}, fmt.Errorf("memcmp filter %q matches %d bytes, more than %d", value, len(decoded), maxMemcmpBytes)
+	}
+	return MemcmpFilter{Offset: parsedOffset, Bytes: data}, nil
+}
+
+// ProgramAccountsFilter narrows getProgramAccounts. A zero DataSize matches
+// any size.
+type ProgramAccountsFilter struct {
+	DataSize uint64
+	Memcmp   []MemcmpFilter
+}
+
+func (f ProgramAccountsFilter) params() []any {
+	var filters []any
+	if f.DataSize > 0 {
+		filters = append(filters, map[string]any{"dataSize": f.DataSize})
+	}
+	for _, memcmp := range f.Memcmp {
+		filters = append(filters, map[string]any{"memcmp": map[string]any{
+			"offset": memcmp.Offset,
+			"bytes":  memcmp.Bytes,
+		}})
+	}
+	return filters
+}
+
+// GetProgramAccounts returns the accounts owned by programID that match
+// filter, sorted by address.
+func (c *Client) GetProgramAccounts(ctx context.Context, programID string, filter ProgramAccountsFilter) ([]KeyedAccount, error) {
+	config := map[string]any{"encoding": "base64", "commitment": "confirmed"}
+	if filters := filter.params(); len(filters) > 0 {
+		config["filters"] = filters
+	}
+	var result []struct {
+		Pubkey  string      `json:"pubkey"`
+		Account *rpcAccount `json:"account"`
+	}
+	if err := c.call(ctx, "getProgramAccounts", []any{programID, config}, &result); err != nil {
+		return nil, err
+	}
+	accounts := make([]KeyedAccount, 0, len(result))
+	for _, keyed := range result {
+		account, err := keyed.Account.decode()
+		if err != nil {
+			return nil, fmt.Errorf("account %s: %w", keyed.Pubkey, err)
+		}
+		if account == nil {
+			continue
+		}
+		accounts = append(accounts, KeyedAccount{Address: keyed.Pubkey, Account: *account})
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].Address < accounts[j].Address })
+	return accounts, nil
+}
+
+// GetProgramAccountKeys returns the addresses of the accounts owned by
+// programID that match filter, sorted. It asks for an empty data slice, so the
+// response stays small however large the accounts are.
+func (c *Client) GetProgramAccountKeys(ctx context.Context, programID string, filter ProgramAccountsFilter) ([]string, error) {
+	config := map[string]any{
+		"encoding":   "base64",
+		"commitment": "confirmed",
+		"dataSlice":  map[string]int{"offset": 0, "length": 0},
+	}
+	if filters := filter.params(); len(filters) > 0 {
+		config["filters"] = filters
+	}
+	var result []struct {
+		Pubkey string `json:"pubkey"`
+	}
+	if err := c.call(ctx, "getProgramAccounts", []any{programID, config}, &result); err != nil {
+		return nil, err
+	}
+	addresses := make([]string, 0, len(result))
+	for _, keyed := range result {
+		addresses = append(addresses, keyed.Pubkey)
+	}
+	sort.Strings(addresses)
+	return addresses, nil
+}
@@ -0,0 +1,104 @@
+package solana
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+// newTestRPC starts a stand-in JSON-RPC server that serves accounts for
+// getAccountInfo and getMultipleAccounts.
+func newTestRPC(t *testing.T, accounts map[string]Account) *Client {
+	t.Helper()
+	encode := func(address string) any {
+		account, ok := accounts[address]
+		if !ok {
+			return nil
+		}
+		return map[string]any{
+			"lamports":   account.Lamports,
+			"data":       []string{base64.StdEncoding.EncodeToString(account.Data), "base64"},
+			"owner":      account.Owner,
+			"executable": account.Executable,
+			"rentEpoch":  account.RentEpoch,
+			"space":      account.Space,
+		}
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Params) == 0 {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		context := map[string]any{"slot": 42}
+		var result any
+		switch req.Method {
+		case "getAccountInfo":
+			var address string
+			_ = json.Unmarshal(req.Params[0], &address)
+			result = map[string]any{"context": context, "value": encode(address)}
+		case "getMultipleAccounts":
+			var addresses []string
+			_ = json.Unmarshal(req.Params[0], &addresses)
+			values := make([]any, 0, len(addresses))
+			for _, address := range addresses {
+				values = append(values, encode(address))
+			}
+			result = map[string]any{"context": context, "value": values}
+		default:
+			http.Error(w, "unsupported method "+req.Method, http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "result": result})
+	}))
+	t.Cleanup(server.Close)
+	return NewClient(server.URL)
+}
+
+func TestAccountSnapshotJSONMatchesCLI(t *testing.T) {
+	const address = "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"
+	data := make([]byte, 82)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	client := newTestRPC(t, map[string]Account{address: {
+		Lamports:  388127047454,
+		Data:      data,
+		Owner:     "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA",
+		RentEpoch: 18446744073709551615,
+		Space:     82,
+	}})
+
+	snapshots, err := FetchAccountSnapshots(context.Background(), client.URL, []string{address, "11111111111111111111111111111112"})
+	if err != nil {
+		t.Fatalf("FetchAccountSnapshots: %v", err)
+	}
+	if len(snapshots) != 1 {
+		t.Fatalf("got %d snapshots, want 1 (missing accounts are skipped)", len(snapshots))
+	}
+	// Output of `solana account <address> --output json-compact`.
+	want, err := os.ReadFile("testdata/account-json-compact.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = bytes.TrimSuffix(want, []byte("\n"))
+	if got := snapshots[address]; !bytes.Equal(got, want) {
+		t.Fatalf("snapshot differs from CLI output\ngot:  %s\nwant: %s", got, want)
+	}
+
+	parsed, account, err := ParseAccountSnapshot(want)
+	if err != nil {
+		t.Fatalf("ParseAccountSnapshot: %v", err)
+	}
+	if parsed != address || !bytes.Equal(account.Data, data) || account.RentEpoch != 18446744073709551615 {
+		t.Fatalf("ParseAccountSnapshot round trip = %s %+v", parsed, account)
+	}
+}
@@ -0,0 +1,182 @@
+package solana
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+)
+
+// Well-known program IDs used when synthesizing token accounts.
+var (
+	TokenProgramID                  = mustPublicKey("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	AssociatedTokenAccountProgramID = mustPublicKey("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+	Token2022ProgramID              = mustPublicKey("TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb")
+)
+
+const (
+	// MintAccountSize and TokenAccountSize are the SPL Token account layouts.
+	MintAccountSize  = 82
+	TokenAccountSize = 165
+
+	// Token-2022 accounts with extensions are padded to the token account size
+	// and followed by an account type byte.
+	token2022AccountTypeOffset = TokenAccountSize
+	token2022AccountTypeMint   = 1
+
+	// Rent-exempt balances for the two layouts: (128 + size) * 3480 * 2.
+	mintAccountLamports  = 1461600
+	tokenAccountLamports = 2039280
+)
+
+func mustPublicKey(value string) PublicKey {
+	key, err := ParsePublicKey(value)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// AssociatedTokenAddress returns the associated token account of owner for mint.
+func AssociatedTokenAddress(owner, mint PublicKey) (PublicKey, error) {
+	address, _, err := FindProgramAddress([][]byte{owner[:], TokenProgramID[:], mint[:]}, AssociatedTokenAccountProgramID)
+	return address, err
+}
+
+// Mint describes an initialized SPL Token mint.
+type Mint struct {
+	MintAuthority   *PublicKey
+	Supply          uint64
+	Decimals        uint8
+	FreezeAuthority *PublicKey
+}
+
+// Encode returns the 82-byte mint account data.
+func (m Mint) Encode() []byte {
+	data := make([]byte, MintAccountSize)
+	putOptionalKey(data[0:36], m.MintAuthority)
+	binary.LittleEndian.PutUint64(data[36:44], m.Supply)
+	data[44] = m.Decimals
+	data[45] = 1 // is_initialized
+	putOptionalKey(data[46:82], m.FreezeAuthority)
+	return data
+}
+
+// TokenAccount describes an initialized, non-native SPL Token account.
+type TokenAccount struct {
+	Mint   PublicKey
+	Owner  PublicKey
+	Amount uint64
+}
+
+// Encode returns the 165-byte token account data.
+func (a TokenAccount) Encode() []byte {
+	data := make([]byte, TokenAccountSize)
+	copy(data[0:32], a.Mint[:])
+	copy(data[32:64], a.Owner[:])
+	binary.LittleEndian.PutUint64(data[64:72], a.Amount)
+	// delegate (36 bytes) stays None.
+	data[108] = 1 // AccountState::Initialized
+	// is_native (12 bytes), delegated_amount (8), and close_authority (36) stay zero.
+	return data
+}
+
+func putOptionalKey(dst []byte, key *PublicKey) {
+	if key == nil {
+		return
+	}
+	binary.LittleEndian.PutUint32(dst[0:4], 1)
+	copy(dst[4:36], key[:])
+}
+
+// accountJSON matches `solana account --output json`, the format
+// solana-test-validator reads with --account.
+type accountJSON struct {
+	Pubkey  string `json:"pubkey"`
+	Account struct {
+		Lamports   uint64    `json:"lamports"`
+		Data       [2]string `json:"data"`
+		Owner      string    `json:"owner"`
+		Executable bool      `json:"executable"`
+		RentEpoch  uint64    `json:"rentEpoch"`
+		Space      int       `json:"space"`
+	} `json:"account"`
+}
+
+// MintAccountJSON renders a mint as an --account snapshot.
+func MintAccountJSON(address PublicKey, mint Mint) ([]byte, error) {
+	return tokenProgramAccountJSON(address, mintAccountLamports, mint.Encode())
+}
+
+// TokenAccountJSON renders a token account as an --account snapshot.
+func TokenAccountJSON(address PublicKey, account TokenAccount) ([]byte, error) {
+	return tokenProgramAccountJSON(address, tokenAccountLamports, account.Encode())
+}
+
+func tokenProgramAccountJSON(address PublicKey, lamports uint64, data []byte) ([]byte, error) {
+	var doc accountJSON
+	doc.Pubkey = address.String()
+	doc.Account.Lamports = lamports
+	doc.Account.Data = [2]string{base64.StdEncoding.EncodeToString(data), "base64"}
+	doc.Account.Owner = TokenProgramID.String()
+	doc.Account.Space = len(data)
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// MintAuthorityOverride replaces the authorities of a cloned mint. A nil
+// FreezeAuthority keeps the mint's existing freeze authority.
+type MintAuthorityOverride struct {
+	MintAuthority   PublicKey
+	FreezeAuthority *PublicKey
+}
+
+// RewriteMintAuthorities applies override to an --account snapshot holding an
+// initialized SPL Token or Token-2022 mint. Any other account is returned
+// unchanged with ok set to false.
+func RewriteMintAuthorities(snapshot []byte, override MintAuthorityOverride) (out []byte, ok bool, err error) {
+	var doc accountJSON
+	if err := json.Unmarshal(snapshot, &doc); err != nil {
+		return nil, false, fmt.Errorf("decode account snapshot: %w", err)
+	}
+	if doc.Account.Data[1] != "base64" {
+		return snapshot, false, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(doc.Account.Data[0])
+	if err != nil {
+		return nil, false, fmt.Errorf("decode account data: %w", err)
+	}
+	if !isMintAccount(doc.Account.Owner, data) {
+		return snapshot, false, nil
+	}
+
+	putOptionalKey(data[0:36], &override.MintAuthority)
+	if override.FreezeAuthority != nil {
+		putOptionalKey(data[46:82], override.FreezeAuthority)
+	}
+	doc.Account.Data[0] = base64.StdEncoding.EncodeToString(data)
+	out, err = json.Marshal(doc)
+	if err != nil {
+		return nil, false, err
+	}
+	return out, true, nil
+}
+
+// isMintAccount reports whether data owned by owner is an initialized mint.
+// Token-2022 mints with extensions are told apart from token accounts by the
+// account type byte.
+func isMintAccount(owner string, data []byte) bool {
+	switch owner {
+	case TokenProgramID.String():
+		if len(data) != MintAccountSize {
+			return false
+		}
+	case Token2022ProgramID.String():
+		if len(data) != MintAccountSize &&
+			(len(data) <= token2022AccountTypeOffset || data[token2022AccountTypeOffset] != token2022AccountTypeMint) {
+			return false
+		}
+	default:
+		return false
+	}
+	return data[45] == 1
+}
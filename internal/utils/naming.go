@@ -16,6 +16,7 @@ const (
 
 var flyAppNamePattern = regexp.MustCompile(`^sol-cloud-[a-z0-9]{8}$`)
 var railwayProjectNamePattern = regexp.MustCompile(`^sol-cloud-[a-z0-9]{8}$`)
+var dockerContainerNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{1,62}$`)
 
 func EnsureFlyAppName(name string) (string, error) {
 	clean := strings.ToLower(strings.TrimSpace(name))
@@ -68,3 +69,16 @@ func GenerateRailwayProjectName() (string, error) {
 	}
 	return railwayProjectPrefix + string(suffix), nil
 }
+
+// EnsureDockerContainerName validates a local Docker container name. Generated
+// Fly-style names are used when the name is empty.
+func EnsureDockerContainerName(name string) (string, error) {
+	clean := strings.ToLower(strings.TrimSpace(name))
+	if clean == "" {
+		return GenerateFlyAppName()
+	}
+	if !dockerContainerNamePattern.MatchString(clean) {
+		return "", fmt.Errorf("container name %q must use lowercase letters, digits, '_', '.', or '-' (example: sol-cloud-1a2b3c4d)", clean)
+	}
+	return clean, nil
+}
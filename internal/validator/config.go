@@ -3,8 +3,13 @@ package validator
 import (
 	"errors"
 	"fmt"
+	"math"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/CharlieAIO/sol-cloud/internal/solana"
 )
 
 const (
@@ -32,6 +37,119 @@ type AirdropEntry struct {
 	Amount  uint64 `mapstructure:"amount" yaml:"amount"`
 }
 
+// AccountFixture loads a JSON account snapshot (`solana account --output json`)
+// into the validator at genesis. Set Address and Path for a single snapshot, or
+// Dir to load every <address>.json file in a directory (the layout written by
+// clone-program).
+type AccountFixture struct {
+	Address string `mapstructure:"address" yaml:"address,omitempty"`
+	Path    string `mapstructure:"path" yaml:"path,omitempty"`
+	Dir     string `mapstructure:"dir" yaml:"dir,omitempty"`
+}
+
+const (
+	DefaultFaucetMaxAmount    uint64 = 10
+	DefaultFaucetAddressQuota uint64 = 50
+	DefaultFaucetIPQuota      uint64 = 100
+	DefaultFaucetWindow              = "24h"
+
+	maxFaucetQuota uint64 = 1_000_000
+)
+
+// FaucetConfig enables a faucet served by nginx at /faucet. Amounts are in
+// SOL. Each request may ask for at most MaxAmount, and each recipient address
+// and client IP may receive at most AddressQuota and IPQuota per Window. While
+// enabled, requestAirdrop is blocked on the public RPC endpoint.
+type FaucetConfig struct {
+	Enabled      bool   `mapstructure:"enabled" yaml:"enabled"`
+	MaxAmount    uint64 `mapstructure:"max_amount" yaml:"max_amount,omitempty"`
+	AddressQuota uint64 `mapstructure:"address_quota" yaml:"address_quota,omitempty"`
+	IPQuota      uint64 `mapstructure:"ip_quota" yaml:"ip_quota,omitempty"`
+	Window       string `mapstructure:"window" yaml:"window,omitempty"`
+}
+
+// WindowDuration parses Window.
+func (f FaucetConfig) WindowDuration() (time.Duration, error) {
+	return time.ParseDuration(strings.TrimSpace(f.Window))
+}
+
+// clusterURLs maps Solana CLI cluster monikers to their public RPC endpoints.
+var clusterURLs = map[string]string{
+	"mainnet":      DefaultCloneRPCURL,
+	"mainnet-beta": DefaultCloneRPCURL,
+	"devnet":       "https://api.devnet.solana.com",
+	"testnet":      "https://api.testnet.solana.com",
+}
+
+// ClusterURL returns the RPC URL for a cluster moniker (mainnet, devnet,
+// testnet) or an http(s) URL, trimmed of a trailing slash. It returns "" for
+// anything else.
+func ClusterURL(value string) string {
+	value = strings.TrimSpace(value)
+	if url, ok := clusterURLs[strings.ToLower(value)]; ok {
+		return url
+	}
+	if strings.HasPrefix(value, "https://") || strings.HasPrefix(value, "http://") {
+		return strings.TrimRight(value, "/")
+	}
+	return ""
+}
+
+// CloneProgram is a clone_programs entry written as an object. Source is a
+// cluster moniker or RPC URL; empty means clone_rpc_url.
+type CloneProgram struct {
+	Address string `mapstructure:"address" yaml:"address"`
+	Source  string `mapstructure:"source" yaml:"source,omitempty"`
+}
+
+// DefaultMaxOwnedAccounts caps how many program-owned accounts a single
+// getProgramAccounts snapshot may return.
+const DefaultMaxOwnedAccounts = 1000
+
+// OwnedAccountsClone snapshots every account owned by Program at deploy time
+// with getProgramAccounts. FilterSize and FilterMemcmp ("offset:base58")
+// narrow the query; more than MaxAccounts matches fails the deploy.
+type OwnedAccountsClone struct {
+	Program      string   `mapstructure:"program" yaml:"program"`
+	FilterSize   uint64   `mapstructure:"filter_size" yaml:"filter_size,omitempty"`
+	FilterMemcmp []string `mapstructure:"filter_memcmp" yaml:"filter_memcmp,omitempty"`
+	MaxAccounts  int      `mapstructure:"max_accounts" yaml:"max_accounts,omitempty"`
+}
+
+// Filter returns the getProgramAccounts filters for the entry.
+func (o OwnedAccountsClone) Filter() (solana.ProgramAccountsFilter, error) {
+	filter := solana.ProgramAccountsFilter{DataSize: o.FilterSize}
+	for _, raw := range o.FilterMemcmp {
+		memcmp, err := solana.ParseMemcmpFilter(raw)
+		if err != nil {
+			return filter, err
+		}
+		filter.Memcmp = append(filter.Memcmp, memcmp)
+	}
+	return filter, nil
+}
+
+// MaxTokenDecimals keeps 10^decimals and token amounts inside a u64.
+const MaxTokenDecimals = 18
+
+// TokenFixture is an SPL Token mint written into genesis together with an
+// associated token account for each holder. Mint and the authorities accept a
+// pubkey or a keypair file path; only the public key is used.
+type TokenFixture struct {
+	Mint            string        `mapstructure:"mint" yaml:"mint"`
+	Decimals        uint8         `mapstructure:"decimals" yaml:"decimals"`
+	MintAuthority   string        `mapstructure:"mint_authority" yaml:"mint_authority,omitempty"`
+	FreezeAuthority string        `mapstructure:"freeze_authority" yaml:"freeze_authority,omitempty"`
+	Holders         []TokenHolder `mapstructure:"holders" yaml:"holders"`
+}
+
+// TokenHolder is a wallet funded with Amount tokens in UI units, so "12.5" with
+// 6 decimals is 12500000 base units.
+type TokenHolder struct {
+	Address string `mapstructure:"address" yaml:"address"`
+	Amount  string `mapstructure:"amount" yaml:"amount"`
+}
+
 // Config holds runtime validator parameters.
 type Config struct {
 	SlotsPerEpoch    uint64 `mapstructure:"slots_per_epoch" yaml:"slots_per_epoch"`
@@ -46,19 +164,49 @@ type Config struct {
 	// BPF upgradeable program and uses --clone-upgradeable-program or --clone
 	// accordingly.
 	ClonePrograms []string `mapstructure:"clone_programs" yaml:"clone_programs"`
+	// SourcedClonePrograms are clone_programs object entries with a source.
+	// Entries whose source is not CloneRPCURL are fetched at deploy time and
+	// loaded with --account, so one validator can mix clusters.
+	SourcedClonePrograms []CloneProgram `mapstructure:"-" yaml:"-"`
 	// Deprecated: use ClonePrograms. Kept for backwards compatibility.
 	CloneAccounts []string `mapstructure:"clone_accounts" yaml:"clone_accounts"`
 	// Deprecated: use ClonePrograms. Kept for backwards compatibility.
 	CloneUpgradeablePrograms []string       `mapstructure:"clone_upgradeable_programs" yaml:"clone_upgradeable_programs"`
 	AirdropAccounts          []AirdropEntry `mapstructure:"airdrop_accounts" yaml:"airdrop_accounts"`
+	// Accounts are JSON account snapshots passed to solana-test-validator with
+	// --account. Like clones, they only take effect when the ledger is created.
+	Accounts []AccountFixture `mapstructure:"accounts" yaml:"accounts"`
+	// TokenFixtures are SPL Token mints and holder balances synthesized as
+	// genesis accounts, so they exist from slot 0.
+	TokenFixtures []TokenFixture `mapstructure:"token_fixtures" yaml:"token_fixtures"`
 	// CloneRPCURL is the RPC endpoint used for --clone and --clone-upgradeable-program
 	// fetches at startup. Defaults to mainnet-beta. Use a private endpoint (Helius,
 	// QuickNode, etc.) if the public endpoint is rate-limited or unreliable.
 	CloneRPCURL string `mapstructure:"clone_rpc_url" yaml:"clone_rpc_url"`
+	// CloneOwnedAccounts are programs whose owned accounts are fetched at deploy
+	// time and loaded with --account.
+	CloneOwnedAccounts []OwnedAccountsClone `mapstructure:"clone_owned_accounts" yaml:"clone_owned_accounts"`
+	// CloneMintAuthority rewrites the mint authority of every SPL Token or
+	// Token-2022 mint in ClonePrograms and CloneAccounts. Those mints are fetched
+	// at deploy time and loaded with --account instead of cloned at startup.
+	// Accepts a pubkey or a keypair file path; only the public key is used.
+	CloneMintAuthority string `mapstructure:"clone_mint_authority" yaml:"clone_mint_authority,omitempty"`
+	// CloneFreezeAuthority optionally rewrites the freeze authority of the same
+	// mints. Requires CloneMintAuthority.
+	CloneFreezeAuthority string `mapstructure:"clone_freeze_authority" yaml:"clone_freeze_authority,omitempty"`
 	// ForceReset clears the ledger on startup so --clone and other args take effect
 	// even when a persistent ledger already exists on disk.
-	ForceReset    bool                `mapstructure:"force_reset" yaml:"force_reset"`
-	ProgramDeploy ProgramDeployConfig `mapstructure:"program_deploy" yaml:"program_deploy"`
+	ForceReset bool `mapstructure:"force_reset" yaml:"force_reset"`
+	// DeactivateFeatures are runtime feature gate pubkeys passed to
+	// solana-test-validator with --deactivate-feature. Like clones, they only
+	// take effect when the ledger is created.
+	DeactivateFeatures []string `mapstructure:"deactivate_features" yaml:"deactivate_features"`
+	// ProgramDeploys are startup program deploys, run in declared order. The
+	// program_deploy key accepts a single object (older configs) or a list.
+	ProgramDeploys []ProgramDeployConfig `mapstructure:"program_deploy" yaml:"program_deploy"`
+	// Faucet is the optional /faucet endpoint for users without direct
+	// requestAirdrop access.
+	Faucet FaucetConfig `mapstructure:"faucet" yaml:"faucet,omitempty"`
 }
 
 // ProgramDeployConfig configures optional startup program deployment.
@@ -66,6 +214,22 @@ type ProgramDeployConfig struct {
 	SOPath               string `mapstructure:"so_path" yaml:"so_path"`
 	ProgramIDKeypairPath string `mapstructure:"program_id_keypair" yaml:"program_id_keypair"`
 	UpgradeAuthorityPath string `mapstructure:"upgrade_authority" yaml:"upgrade_authority"`
+	// Mode is "runtime" (default): deploy with `solana program deploy` after the
+	// RPC is healthy, or "genesis": load the program at slot 0 with
+	// --upgradeable-program, or --bpf-program when no upgrade authority is set.
+	// In genesis mode program_id_keypair and upgrade_authority may also be pubkeys.
+	Mode string `mapstructure:"mode" yaml:"mode,omitempty"`
+}
+
+const (
+	ProgramDeployModeRuntime = "runtime"
+	ProgramDeployModeGenesis = "genesis"
+)
+
+// IsGenesis returns true when the program is loaded into genesis instead of
+// deployed after startup.
+func (p ProgramDeployConfig) IsGenesis() bool {
+	return strings.EqualFold(strings.TrimSpace(p.Mode), ProgramDeployModeGenesis)
 }
 
 // HasValues returns true when any program deploy field is configured.
@@ -75,8 +239,12 @@ func (p ProgramDeployConfig) HasValues() bool {
 		strings.TrimSpace(p.UpgradeAuthorityPath) != ""
 }
 
-// Enabled returns true when all required startup deploy fields are configured.
+// Enabled returns true when all fields required by the deploy mode are configured.
 func (p ProgramDeployConfig) Enabled() bool {
+	if p.IsGenesis() {
+		return strings.TrimSpace(p.SOPath) != "" &&
+			strings.TrimSpace(p.ProgramIDKeypairPath) != ""
+	}
 	return strings.TrimSpace(p.SOPath) != "" &&
 		strings.TrimSpace(p.ProgramIDKeypairPath) != "" &&
 		strings.TrimSpace(p.UpgradeAuthorityPath) != ""
@@ -94,8 +262,12 @@ func DefaultConfig() Config {
 		ClonePrograms:            []string{},
 		CloneAccounts:            []string{},
 		CloneUpgradeablePrograms: []string{},
+		CloneOwnedAccounts:       []OwnedAccountsClone{},
 		AirdropAccounts:          []AirdropEntry{},
-		ProgramDeploy:            ProgramDeployConfig{},
+		Accounts:                 []AccountFixture{},
+		TokenFixtures:            []TokenFixture{},
+		DeactivateFeatures:       []string{},
+		ProgramDeploys:           []ProgramDeployConfig{},
 	}
 }
 
@@ -131,14 +303,48 @@ func (c *Config) ApplyDefaults() {
 	if c.CloneUpgradeablePrograms == nil {
 		c.CloneUpgradeablePrograms = []string{}
 	}
+	if c.CloneOwnedAccounts == nil {
+		c.CloneOwnedAccounts = []OwnedAccountsClone{}
+	}
+	for i, entry := range c.CloneOwnedAccounts {
+		if entry.MaxAccounts == 0 {
+			c.CloneOwnedAccounts[i].MaxAccounts = DefaultMaxOwnedAccounts
+		}
+	}
 	if c.AirdropAccounts == nil {
 		c.AirdropAccounts = []AirdropEntry{}
 	}
+	if c.Accounts == nil {
+		c.Accounts = []AccountFixture{}
+	}
+	if c.TokenFixtures == nil {
+		c.TokenFixtures = []TokenFixture{}
+	}
+	if c.DeactivateFeatures == nil {
+		c.DeactivateFeatures = []string{}
+	}
+	if c.ProgramDeploys == nil {
+		c.ProgramDeploys = []ProgramDeployConfig{}
+	}
 	for i, entry := range c.AirdropAccounts {
 		if entry.Amount == 0 {
 			c.AirdropAccounts[i].Amount = DefaultAirdropAmount
 		}
 	}
+	if c.Faucet.Enabled {
+		if c.Faucet.MaxAmount == 0 {
+			c.Faucet.MaxAmount = DefaultFaucetMaxAmount
+		}
+		if c.Faucet.AddressQuota == 0 {
+			c.Faucet.AddressQuota = DefaultFaucetAddressQuota
+		}
+		if c.Faucet.IPQuota == 0 {
+			c.Faucet.IPQuota = DefaultFaucetIPQuota
+		}
+		if strings.TrimSpace(c.Faucet.Window) == "" {
+			c.Faucet.Window = DefaultFaucetWindow
+		}
+	}
 }
 
 // Validate ensures configuration values are inside safe ranges.
@@ -164,18 +370,66 @@ func (c Config) Validate() error {
 	if err := validateAddressList("clone_programs", c.ClonePrograms); err != nil {
 		return err
 	}
+	if err := validateSourcedClonePrograms(c.SourcedClonePrograms, c.ClonePrograms); err != nil {
+		return err
+	}
 	if err := validateAddressList("clone_accounts", c.CloneAccounts); err != nil {
 		return err
 	}
 	if err := validateAddressList("clone_upgradeable_programs", c.CloneUpgradeablePrograms); err != nil {
 		return err
 	}
+	if err := validateAddressList("deactivate_features", c.DeactivateFeatures); err != nil {
+		return err
+	}
+	if err := validateOwnedAccountsClones(c.CloneOwnedAccounts); err != nil {
+		return err
+	}
+	if strings.TrimSpace(c.CloneFreezeAuthority) != "" && strings.TrimSpace(c.CloneMintAuthority) == "" {
+		return errors.New("clone_freeze_authority requires clone_mint_authority")
+	}
 	if err := validateAirdropAccounts(c.AirdropAccounts); err != nil {
 		return err
 	}
-	if err := validateProgramDeploy(c.ProgramDeploy); err != nil {
+	if err := validateAccountFixtures(c.Accounts); err != nil {
 		return err
 	}
+	if err := validateTokenFixtures(c.TokenFixtures); err != nil {
+		return err
+	}
+	if err := validateProgramDeploys(c.ProgramDeploys); err != nil {
+		return err
+	}
+	if err := validateFaucet(c.Faucet); err != nil {
+		return err
+	}
+	return nil
+}
+
+func validateFaucet(f FaucetConfig) error {
+	if !f.Enabled {
+		return nil
+	}
+	if f.MaxAmount == 0 {
+		return errors.New("faucet.max_amount must be >= 1")
+	}
+	if f.AddressQuota < f.MaxAmount {
+		return errors.New("faucet.address_quota must be >= faucet.max_amount")
+	}
+	if f.IPQuota < f.MaxAmount {
+		return errors.New("faucet.ip_quota must be >= faucet.max_amount")
+	}
+	// nginx tracks quotas as lamports in doubles, exact up to 2^53.
+	if f.AddressQuota > maxFaucetQuota || f.IPQuota > maxFaucetQuota {
+		return fmt.Errorf("faucet quotas must be <= %d", maxFaucetQuota)
+	}
+	window, err := f.WindowDuration()
+	if err != nil {
+		return fmt.Errorf("faucet.window %q: %w", f.Window, err)
+	}
+	if window < time.Minute {
+		return errors.New("faucet.window must be at least 1m")
+	}
 	return nil
 }
 
@@ -197,6 +451,44 @@ func validateAddressList(key string, values []string) error {
 	return nil
 }
 
+func validateSourcedClonePrograms(entries []CloneProgram, plain []string) error {
+	seen := map[string]struct{}{}
+	for _, address := range plain {
+		seen[strings.TrimSpace(address)] = struct{}{}
+	}
+	for _, entry := range entries {
+		address := strings.TrimSpace(entry.Address)
+		if !IsAddress(address) {
+			return fmt.Errorf("clone_programs contains invalid address %q", entry.Address)
+		}
+		if _, ok := seen[address]; ok {
+			return fmt.Errorf("clone_programs contains duplicate address %q", address)
+		}
+		seen[address] = struct{}{}
+		if strings.TrimSpace(entry.Source) != "" && ClusterURL(entry.Source) == "" {
+			return fmt.Errorf("clone_programs entry %s has source %q; use mainnet, devnet, testnet, or an http(s) RPC URL", address, entry.Source)
+		}
+	}
+	return nil
+}
+
+func validateOwnedAccountsClones(entries []OwnedAccountsClone) error {
+	for i, entry := range entries {
+		key := fmt.Sprintf("clone_owned_accounts[%d]", i)
+		program := strings.TrimSpace(entry.Program)
+		if !base58AddressPattern.MatchString(program) {
+			return fmt.Errorf("%s.program must be a program address, got %q", key, program)
+		}
+		if entry.MaxAccounts < 0 {
+			return fmt.Errorf("%s.max_accounts must be positive", key)
+		}
+		if _, err := entry.Filter(); err != nil {
+			return fmt.Errorf("%s.filter_memcmp: %w", key, err)
+		}
+	}
+	return nil
+}
+
 func validateAirdropAccounts(entries []AirdropEntry) error {
 	seen := map[string]struct{}{}
 	for _, entry := range entries {
@@ -218,7 +510,143 @@ func validateAirdropAccounts(entries []AirdropEntry) error {
 	return nil
 }
 
-func validateProgramDeploy(cfg ProgramDeployConfig) error {
+// IsAddress reports whether value looks like a base58 Solana address.
+func IsAddress(value string) bool {
+	return base58AddressPattern.MatchString(strings.TrimSpace(value))
+}
+
+func validateAccountFixtures(entries []AccountFixture) error {
+	seen := map[string]struct{}{}
+	for i, entry := range entries {
+		addr := strings.TrimSpace(entry.Address)
+		path := strings.TrimSpace(entry.Path)
+		dir := strings.TrimSpace(entry.Dir)
+		if dir != "" {
+			if addr != "" || path != "" {
+				return fmt.Errorf("accounts[%d]: set either dir or address and path, not both", i)
+			}
+			continue
+		}
+		if addr == "" {
+			return fmt.Errorf("accounts[%d]: address is required unless dir is set", i)
+		}
+		if !base58AddressPattern.MatchString(addr) {
+			return fmt.Errorf("accounts contains invalid address %q", addr)
+		}
+		if path == "" {
+			return fmt.Errorf("accounts entry for %q requires a path to a JSON account snapshot", addr)
+		}
+		if _, ok := seen[addr]; ok {
+			return fmt.Errorf("accounts contains duplicate address %q", addr)
+		}
+		seen[addr] = struct{}{}
+	}
+	return nil
+}
+
+func validateTokenFixtures(fixtures []TokenFixture) error {
+	mints := map[string]struct{}{}
+	for i, fixture := range fixtures {
+		key := fmt.Sprintf("token_fixtures[%d]", i)
+		mint := strings.TrimSpace(fixture.Mint)
+		if mint == "" {
+			return fmt.Errorf("%s.mint is required (pubkey or keypair path)", key)
+		}
+		if _, ok := mints[mint]; ok {
+			return fmt.Errorf("%s.mint %q is configured more than once", key, mint)
+		}
+		mints[mint] = struct{}{}
+		if fixture.Decimals > MaxTokenDecimals {
+			return fmt.Errorf("%s.decimals must be <= %d", key, MaxTokenDecimals)
+		}
+		if len(fixture.Holders) == 0 {
+			return fmt.Errorf("%s.holders must list at least one wallet", key)
+		}
+		if _, err := TokenFixtureSupply(fixture); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		holders := map[string]struct{}{}
+		for _, holder := range fixture.Holders {
+			addr := strings.TrimSpace(holder.Address)
+			if !base58AddressPattern.MatchString(addr) {
+				return fmt.Errorf("%s.holders contains invalid address %q", key, addr)
+			}
+			if _, ok := holders[addr]; ok {
+				return fmt.Errorf("%s.holders contains duplicate address %q", key, addr)
+			}
+			holders[addr] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// TokenFixtureSupply returns the mint supply in base units: the sum of every
+// holder amount.
+func TokenFixtureSupply(fixture TokenFixture) (uint64, error) {
+	var supply uint64
+	for _, holder := range fixture.Holders {
+		amount, err := ParseTokenAmount(holder.Amount, fixture.Decimals)
+		if err != nil {
+			return 0, fmt.Errorf("holder %s: %w", strings.TrimSpace(holder.Address), err)
+		}
+		if supply > math.MaxUint64-amount {
+			return 0, errors.New("total holder amount overflows the mint supply")
+		}
+		supply += amount
+	}
+	return supply, nil
+}
+
+// ParseTokenAmount converts a UI amount such as "1000" or "12.5" into base
+// units for a mint with the given decimals.
+func ParseTokenAmount(amount string, decimals uint8) (uint64, error) {
+	amount = strings.TrimSpace(amount)
+	whole, frac, _ := strings.Cut(amount, ".")
+	if whole == "" && frac == "" {
+		return 0, errors.New("amount is required")
+	}
+	if len(frac) > int(decimals) {
+		return 0, fmt.Errorf("amount %q has more than %d decimal places", amount, decimals)
+	}
+	digits := whole + frac + strings.Repeat("0", int(decimals)-len(frac))
+	value, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("amount %q must be a positive number that fits the mint supply", amount)
+	}
+	if value == 0 {
+		return 0, fmt.Errorf("amount %q must be greater than zero", amount)
+	}
+	return value, nil
+}
+
+// validateProgramDeploys checks every entry and rejects two entries that
+// deploy to the same program ID keypair.
+func validateProgramDeploys(entries []ProgramDeployConfig) error {
+	seen := map[string]struct{}{}
+	for i, entry := range entries {
+		key := "program_deploy"
+		if len(entries) > 1 {
+			key = fmt.Sprintf("program_deploy[%d]", i)
+		}
+		if !entry.HasValues() {
+			if len(entries) > 1 {
+				return fmt.Errorf("%s is empty", key)
+			}
+			continue
+		}
+		if err := validateProgramDeploy(key, entry); err != nil {
+			return err
+		}
+		programIDKeypair := strings.TrimSpace(entry.ProgramIDKeypairPath)
+		if _, ok := seen[programIDKeypair]; ok {
+			return fmt.Errorf("%s.program_id_keypair %q is already used by another program_deploy entry", key, programIDKeypair)
+		}
+		seen[programIDKeypair] = struct{}{}
+	}
+	return nil
+}
+
+func validateProgramDeploy(key string, cfg ProgramDeployConfig) error {
 	soPath := strings.TrimSpace(cfg.SOPath)
 	programIDKeypair := strings.TrimSpace(cfg.ProgramIDKeypairPath)
 	upgradeAuthority := strings.TrimSpace(cfg.UpgradeAuthorityPath)
@@ -226,23 +654,46 @@ func validateProgramDeploy(cfg ProgramDeployConfig) error {
 	if soPath == "" && programIDKeypair == "" && upgradeAuthority == "" {
 		return nil
 	}
+	switch strings.ToLower(strings.TrimSpace(cfg.Mode)) {
+	case "", ProgramDeployModeRuntime:
+	case ProgramDeployModeGenesis:
+		return validateGenesisProgram(key, soPath, programIDKeypair)
+	default:
+		return fmt.Errorf("%s.mode must be %q or %q", key, ProgramDeployModeRuntime, ProgramDeployModeGenesis)
+	}
 	if soPath == "" {
-		return errors.New("program_deploy.so_path is required when program_deploy is configured")
+		return fmt.Errorf("%s.so_path is required when program_deploy is configured", key)
 	}
 	if !strings.HasSuffix(strings.ToLower(soPath), ".so") {
-		return errors.New("program_deploy.so_path must point to a .so file")
+		return fmt.Errorf("%s.so_path must point to a .so file", key)
 	}
 	if programIDKeypair == "" {
-		return errors.New("program_deploy.program_id_keypair is required when program_deploy is configured")
+		return fmt.Errorf("%s.program_id_keypair is required when program_deploy is configured", key)
 	}
 	if base58AddressPattern.MatchString(programIDKeypair) {
-		return errors.New("program_deploy.program_id_keypair must be a keypair file path, not a pubkey")
+		return fmt.Errorf("%s.program_id_keypair must be a keypair file path, not a pubkey", key)
 	}
 	if upgradeAuthority == "" {
-		return errors.New("program_deploy.upgrade_authority is required when program_deploy is configured")
+		return fmt.Errorf("%s.upgrade_authority is required when program_deploy is configured", key)
 	}
 	if base58AddressPattern.MatchString(upgradeAuthority) {
-		return errors.New("program_deploy.upgrade_authority must be a keypair file path, not a pubkey")
+		return fmt.Errorf("%s.upgrade_authority must be a keypair file path, not a pubkey", key)
+	}
+	return nil
+}
+
+// validateGenesisProgram checks a mode: genesis entry. The program ID and
+// upgrade authority are passed straight to solana-test-validator, which accepts
+// either a pubkey or a keypair file, and the authority is optional.
+func validateGenesisProgram(key, soPath, programID string) error {
+	if soPath == "" {
+		return fmt.Errorf("%s.so_path is required when program_deploy is configured", key)
+	}
+	if !strings.HasSuffix(strings.ToLower(soPath), ".so") {
+		return fmt.Errorf("%s.so_path must point to a .so file", key)
+	}
+	if programID == "" {
+		return fmt.Errorf("%s.program_id_keypair (keypair path or pubkey) is required in genesis mode", key)
 	}
 	return nil
 }